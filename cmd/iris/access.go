@@ -0,0 +1,142 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+
+	"github.com/b401/goiris"
+)
+
+var accessCommands = map[string]func(a *app, args []string) error{
+	"list":   accessList,
+	"grant":  accessGrant,
+	"revoke": accessRevoke,
+	"check":  accessCheck,
+}
+
+// accessTargetFlags adds -user and -group to fs, the returned function resolves the one given to an ID
+func accessTargetFlags(a *app, fs *flag.FlagSet) func() (userId, groupId int, err error) {
+	user := fs.String("user", "", "user login")
+	group := fs.String("group", "", "group name")
+
+	return func() (int, int, error) {
+		switch {
+		case (*user == "") == (*group == ""):
+			return 0, 0, fmt.Errorf("%w: either -user or -group is required", errUsage)
+		case *user != "":
+			userId, err := a.client.Lookup().UserID(*user)
+			return userId, 0, err
+		default:
+			groupId, err := a.client.Lookup().GroupID(*group)
+			return 0, groupId, err
+		}
+	}
+}
+
+func accessList(a *app, args []string) error {
+	fs, caseId := caseFlagSet("list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+
+	users, err := a.client.ListCaseUsers(*caseId)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, user := range users.Users {
+		rows = append(rows, []string{itoa(user.UserID), user.UserLogin, user.UserName, user.AccessLevel.String()})
+	}
+	return a.render(users.Users, []string{"id", "login", "name", "access"}, rows)
+}
+
+func accessGrant(a *app, args []string) error {
+	fs, caseId := caseFlagSet("grant")
+	target := accessTargetFlags(a, fs)
+	levelName := fs.String("level", "read_only", "access level: read_only, full_access or deny_all")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	level, err := goiris.ParseCaseAccessLevel(*levelName)
+	if err != nil {
+		return err
+	}
+	userId, groupId, err := target()
+	if err != nil {
+		return err
+	}
+
+	if userId != 0 {
+		_, err = a.client.SetUserCaseAccess(userId, level, *caseId)
+	} else {
+		_, err = a.client.SetGroupCaseAccess(groupId, level, *caseId)
+	}
+	return err
+}
+
+func accessRevoke(a *app, args []string) error {
+	fs, caseId := caseFlagSet("revoke")
+	target := accessTargetFlags(a, fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	userId, groupId, err := target()
+	if err != nil {
+		return err
+	}
+
+	if userId != 0 {
+		return a.client.RemoveUserCaseAccess(userId, *caseId)
+	}
+	return a.client.RemoveGroupCaseAccess(groupId, *caseId)
+}
+
+// accessCheck shows where the access of a user comes from and the resulting effective level
+func accessCheck(a *app, args []string) error {
+	fs, caseId := caseFlagSet("check")
+	login := fs.String("user", "", "user login (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	if *login == "" {
+		return fmt.Errorf("%w: -user is required", errUsage)
+	}
+	userId, err := a.client.Lookup().UserID(*login)
+	if err != nil {
+		return err
+	}
+
+	grants, err := a.client.GetCaseAccessGrants(userId, *caseId)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	if grants.User != nil {
+		rows = append(rows, []string{"user " + *login, grants.User.String()})
+	}
+	groups := make([]string, 0, len(grants.Groups))
+	for group := range grants.Groups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		rows = append(rows, []string{"group " + group, grants.Groups[group].String()})
+	}
+	rows = append(rows, []string{"effective", grants.Effective().String()})
+	return a.render(grants, []string{"source", "access"}, rows)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/b401/goiris"
+)
+
+var activityCommands = map[string]func(a *app, args []string) error{
+	"list":   activitiesList,
+	"export": activitiesExport,
+}
+
+// activityFilterFlags adds the flags of goiris.ActivityFilter to fs, the returned function builds the filter
+func activityFilterFlags(fs *flag.FlagSet) func() (goiris.ActivityFilter, error) {
+	caseId := fs.Int("case", 0, "case ID (default all cases)")
+	from := fs.String("from", "", "only activities since this RFC 3339 time or date")
+	to := fs.String("to", "", "only activities before this RFC 3339 time or date")
+	user := fs.String("user", "", "only activities of this user name")
+
+	return func() (goiris.ActivityFilter, error) {
+		filter := goiris.ActivityFilter{CaseID: *caseId, User: *user}
+		var err error
+		if filter.From, err = parseTimeFlag(*from); err != nil {
+			return filter, err
+		}
+		if filter.To, err = parseTimeFlag(*to); err != nil {
+			return filter, err
+		}
+		return filter, nil
+	}
+}
+
+// parseTimeFlag parses an RFC 3339 time or a date, the zero time if value is empty
+func parseTimeFlag(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return t, fmt.Errorf("%w: invalid time %q", errUsage, value)
+	}
+	return t, nil
+}
+
+func activitiesList(a *app, args []string) error {
+	fs := newFlagSet("list")
+	filterFlags := activityFilterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	filter, err := filterFlags()
+	if err != nil {
+		return err
+	}
+
+	activities, err := a.client.FilterActivities(filter)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, activity := range activities {
+		rows = append(rows, []string{activity.ActivityDate, activity.UserName, itoa(activity.CaseID), activity.CaseName, activity.ActivityDesc})
+	}
+	return a.render(activities, []string{"date", "user", "case", "case name", "activity"}, rows)
+}
+
+func activitiesExport(a *app, args []string) error {
+	fs := newFlagSet("export")
+	filterFlags := activityFilterFlags(fs)
+	format := fs.String("format", "jsonl", "line format: jsonl or syslog")
+	out := fs.String("out", "", "output file, appended to (default stdout)")
+	hostname := fs.String("hostname", "", "syslog: HOSTNAME field (default the local hostname)")
+	appName := fs.String("app", "", "syslog: APP-NAME field (default iris)")
+	facility := fs.Int("facility", goiris.SyslogFacilityLogAudit, "syslog: facility number")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	filter, err := filterFlags()
+	if err != nil {
+		return err
+	}
+
+	activities, err := a.client.FilterActivities(filter)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = a.stdout
+	if *out != "" && *out != "-" {
+		f, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	options := goiris.ActivitySyslogOptions{Hostname: *hostname, AppName: *appName}
+	// facility 0 is kern, so only a given flag is passed on
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "facility" {
+			options.Facility = facility
+		}
+	})
+	return goiris.WriteActivities(w, activities, goiris.ActivityExportFormat(*format), options)
+}
@@ -0,0 +1,682 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/b401/goiris"
+)
+
+var caseCommands = map[string]func(a *app, args []string) error{
+	"list":        casesList,
+	"get":         casesGet,
+	"add":         casesAdd,
+	"close":       casesClose,
+	"reopen":      casesReopen,
+	"delete":      casesDelete,
+	"export":      casesExport,
+	"import":      casesImport,
+	"export-misp": casesExportMisp,
+	"import-misp": casesImportMisp,
+}
+
+var assetCommands = map[string]func(a *app, args []string) error{
+	"list":   assetsList,
+	"get":    assetsGet,
+	"add":    assetsAdd,
+	"delete": assetsDelete,
+}
+
+var iocCommands = map[string]func(a *app, args []string) error{
+	"list":        iocsList,
+	"get":         iocsGet,
+	"add":         iocsAdd,
+	"delete":      iocsDelete,
+	"export-stix": iocsExportStix,
+	"import-stix": iocsImportStix,
+}
+
+var taskCommands = map[string]func(a *app, args []string) error{
+	"list":   tasksList,
+	"get":    tasksGet,
+	"add":    tasksAdd,
+	"delete": tasksDelete,
+}
+
+var noteCommands = map[string]func(a *app, args []string) error{
+	"list":   notesList,
+	"get":    notesGet,
+	"add":    notesAdd,
+	"delete": notesDelete,
+}
+
+var caseHeaders = []string{"id", "name", "customer", "soc id", "state", "opened", "closed"}
+
+func caseRow(c goiris.Case) []string {
+	return []string{itoa(c.CaseID), c.CaseName, c.ClientName, c.CaseSocID, c.StateName, c.CaseOpenDate, c.CaseCloseDate}
+}
+
+func casesList(a *app, args []string) error {
+	fs := newFlagSet("list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cases, err := a.client.ListCases()
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, c := range cases.Cases {
+		rows = append(rows, caseRow(c))
+	}
+	return a.render(cases.Cases, caseHeaders, rows)
+}
+
+func casesGet(a *app, args []string) error {
+	fs := newFlagSet("get")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "case-id")
+	if err != nil {
+		return err
+	}
+
+	c, err := a.client.GetCase(ids[0])
+	if err != nil {
+		return err
+	}
+	return a.render(c.Case, caseHeaders, [][]string{caseRow(c.Case)})
+}
+
+func casesAdd(a *app, args []string) error {
+	fs := newFlagSet("add")
+	customer := fs.Int("customer", 0, "customer ID (required)")
+	name := fs.String("name", "", "case name (required)")
+	description := fs.String("description", "", "case description")
+	socId := fs.String("soc-id", "", "SOC ticket ID")
+	classification := fs.String("classification", "", "classification name, e.g. malicious-code:ransomware")
+	template := fs.Int("template", 0, "case template ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *customer == 0 || *name == "" {
+		return fmt.Errorf("%w: -customer and -name are required", errUsage)
+	}
+
+	request := goiris.AddCaseRequest{
+		CaseCustomer:    *customer,
+		CaseName:        *name,
+		CaseDescription: *description,
+		CaseSocID:       *socId,
+		CaseTemplateID:  *template,
+	}
+	if *classification != "" {
+		classificationId, err := a.client.Lookup().CaseClassificationID(*classification)
+		if err != nil {
+			return err
+		}
+		request.ClassificationID = classificationId
+	}
+
+	c, err := a.client.AddCase(request)
+	if err != nil {
+		return err
+	}
+	return a.render(c.Case, caseHeaders, [][]string{caseRow(c.Case)})
+}
+
+func casesClose(a *app, args []string) error {
+	fs := newFlagSet("close")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "case-id")
+	if err != nil {
+		return err
+	}
+
+	c, err := a.client.CloseCase(ids[0])
+	if err != nil {
+		return err
+	}
+	return a.render(c.Case, caseHeaders, [][]string{caseRow(c.Case)})
+}
+
+func casesReopen(a *app, args []string) error {
+	fs := newFlagSet("reopen")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "case-id")
+	if err != nil {
+		return err
+	}
+
+	c, err := a.client.ReopenCase(ids[0])
+	if err != nil {
+		return err
+	}
+	return a.render(c.Case, caseHeaders, [][]string{caseRow(c.Case)})
+}
+
+func casesDelete(a *app, args []string) error {
+	fs := newFlagSet("delete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "case-id")
+	if err != nil {
+		return err
+	}
+
+	return a.client.DeleteCase(ids[0])
+}
+
+func casesExport(a *app, args []string) error {
+	fs := newFlagSet("export")
+	out := fs.String("out", "", "archive file (default case-<id>.<format>)")
+	format := fs.String("format", "zip", "archive format: zip or tar.gz")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "case-id")
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		*out = fmt.Sprintf("case-%d.%s", ids[0], *format)
+	}
+	file, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := a.client.ExportCase(ids[0], file, goiris.CaseArchiveFormat(*format))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*out)
+		return err
+	}
+
+	var rows [][]string
+	for _, entry := range manifest.Entries {
+		rows = append(rows, []string{entry.Path, fmt.Sprint(entry.Size), entry.SHA256})
+	}
+	return a.render(manifest, []string{"path", "size", "sha256"}, rows)
+}
+
+func casesImport(a *app, args []string) error {
+	fs := newFlagSet("import")
+	file := fs.String("f", "", "case archive written by cases export (required)")
+	customer := fs.String("customer", "", "customer of the new case (default the customer of the archive)")
+	progressFile := fs.String("progress", "", "progress file to resume an interrupted import (default <archive>.progress.json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("%w: -f is required", errUsage)
+	}
+	if *progressFile == "" {
+		*progressFile = *file + ".progress.json"
+	}
+
+	snapshot, _, err := goiris.ReadCaseArchive(*file)
+	if err != nil {
+		return err
+	}
+	result, err := a.client.ImportCase(snapshot, goiris.CaseImportOptions{
+		CustomerName: *customer,
+		ProgressFile: *progressFile,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range result.Warnings {
+		fmt.Fprintln(os.Stderr, "warning:", warning)
+	}
+	return a.render(result, []string{"case id", "source case id"}, [][]string{{itoa(result.CaseID), itoa(result.Progress.SourceCaseID)}})
+}
+
+// caseFlagSet returns a flag set with the -case flag every case object command needs
+func caseFlagSet(name string) (*flag.FlagSet, *int) {
+	fs := newFlagSet(name)
+	caseId := fs.Int("case", 0, "case ID (required)")
+	return fs, caseId
+}
+
+var assetHeaders = []string{"id", "name", "type", "ip", "domain", "status", "tags"}
+
+func assetRow(asset goiris.Asset) []string {
+	return []string{itoa(asset.AssetID), asset.AssetName, asset.AssetType, asset.AssetIP, asset.AssetDomain, asset.AnalysisStatus, asset.AssetTags}
+}
+
+func assetsList(a *app, args []string) error {
+	fs, caseId := caseFlagSet("list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+
+	assets, err := a.client.ListAssets(*caseId)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, asset := range assets.Data.Assets {
+		rows = append(rows, assetRow(asset))
+	}
+	return a.render(assets.Data.Assets, assetHeaders, rows)
+}
+
+func assetsGet(a *app, args []string) error {
+	fs, caseId := caseFlagSet("get")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "asset-id")
+	if err != nil {
+		return err
+	}
+
+	asset, err := a.client.GetAsset(*caseId, ids[0])
+	if err != nil {
+		return err
+	}
+	return a.render(asset.Asset, assetHeaders, [][]string{assetRow(asset.Asset)})
+}
+
+func assetsAdd(a *app, args []string) error {
+	fs, caseId := caseFlagSet("add")
+	name := fs.String("name", "", "asset name (required)")
+	assetType := fs.String("type", "", "asset type name, e.g. \"Windows - Computer\" (required)")
+	status := fs.String("status", "Unspecified", "analysis status name")
+	ip := fs.String("ip", "", "asset IP")
+	domain := fs.String("domain", "", "asset domain")
+	description := fs.String("description", "", "asset description")
+	tags := fs.String("tags", "", "comma separated tags")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	if *name == "" || *assetType == "" {
+		return fmt.Errorf("%w: -name and -type are required", errUsage)
+	}
+
+	assetTypeId, err := a.client.Lookup().AssetTypeID(*assetType)
+	if err != nil {
+		return err
+	}
+	statusId, err := a.client.Lookup().AnalysisStatusID(*status)
+	if err != nil {
+		return err
+	}
+
+	asset, err := a.client.AddAsset(*caseId, goiris.AssetRequest{
+		AssetName:        *name,
+		AssetTypeID:      assetTypeId,
+		AnalysisStatusID: statusId,
+		AssetIP:          *ip,
+		AssetDomain:      *domain,
+		AssetDescription: *description,
+		AssetTags:        *tags,
+	})
+	if err != nil {
+		return err
+	}
+	return a.render(asset.Asset, assetHeaders, [][]string{assetRow(asset.Asset)})
+}
+
+func assetsDelete(a *app, args []string) error {
+	fs, caseId := caseFlagSet("delete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "asset-id")
+	if err != nil {
+		return err
+	}
+
+	return a.client.DeleteAsset(*caseId, ids[0])
+}
+
+var iocHeaders = []string{"id", "value", "type", "tlp", "tags", "description"}
+
+func iocRow(ioc goiris.Ioc) []string {
+	return []string{itoa(ioc.IocID), ioc.IocValue, ioc.IocType, ioc.TlpName, ioc.IocTags, ioc.IocDescription}
+}
+
+func iocsList(a *app, args []string) error {
+	fs, caseId := caseFlagSet("list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+
+	iocs, err := a.client.ListIocs(*caseId)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, ioc := range iocs.Data.Iocs {
+		rows = append(rows, iocRow(ioc))
+	}
+	return a.render(iocs.Data.Iocs, iocHeaders, rows)
+}
+
+func iocsGet(a *app, args []string) error {
+	fs, caseId := caseFlagSet("get")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "ioc-id")
+	if err != nil {
+		return err
+	}
+
+	ioc, err := a.client.GetIoc(*caseId, ids[0])
+	if err != nil {
+		return err
+	}
+	return a.render(ioc.Ioc, iocHeaders, [][]string{iocRow(ioc.Ioc)})
+}
+
+func iocsAdd(a *app, args []string) error {
+	fs, caseId := caseFlagSet("add")
+	value := fs.String("value", "", "IOC value (required)")
+	iocType := fs.String("type", "", "IOC type name, e.g. ip-dst (required)")
+	tlp := fs.String("tlp", "amber", "TLP name")
+	description := fs.String("description", "", "IOC description")
+	tags := fs.String("tags", "", "comma separated tags")
+	noValidate := fs.Bool("no-validate", false, "skip the local validation against the IOC type regex")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	if *value == "" || *iocType == "" {
+		return fmt.Errorf("%w: -value and -type are required", errUsage)
+	}
+
+	iocTypeId, err := a.client.Lookup().IocTypeID(*iocType)
+	if err != nil {
+		return err
+	}
+	tlpId, err := a.client.Lookup().TlpID(*tlp)
+	if err != nil {
+		return err
+	}
+	if !*noValidate {
+		err := a.client.ValidateIocValue(iocTypeId, *value)
+		if errors.Is(err, goiris.ErrIocTypeRegexUnsupported) {
+			fmt.Fprintln(os.Stderr, "warning:", err, "- left to IRIS")
+		} else if err != nil {
+			return err
+		}
+	}
+
+	ioc, err := a.client.AddIoc(*caseId, goiris.IocRequest{
+		IocValue:       *value,
+		IocTypeID:      iocTypeId,
+		IocTlpID:       tlpId,
+		IocDescription: *description,
+		IocTags:        *tags,
+	})
+	if err != nil {
+		return err
+	}
+	return a.render(ioc.Ioc, iocHeaders, [][]string{iocRow(ioc.Ioc)})
+}
+
+func iocsDelete(a *app, args []string) error {
+	fs, caseId := caseFlagSet("delete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "ioc-id")
+	if err != nil {
+		return err
+	}
+
+	return a.client.DeleteIoc(*caseId, ids[0])
+}
+
+var taskHeaders = []string{"id", "title", "status", "assignees", "tags"}
+
+func taskRow(task goiris.CaseTask) []string {
+	assignees := ""
+	for i, assignee := range task.TaskAssignees {
+		if i > 0 {
+			assignees += ", "
+		}
+		assignees += assignee.Name
+	}
+	return []string{itoa(task.TaskID), task.TaskTitle, task.StatusName, assignees, task.TaskTags}
+}
+
+func tasksList(a *app, args []string) error {
+	fs, caseId := caseFlagSet("list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+
+	tasks, err := a.client.ListCaseTasks(*caseId)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, task := range tasks.Data.Tasks {
+		rows = append(rows, taskRow(task))
+	}
+	return a.render(tasks.Data.Tasks, taskHeaders, rows)
+}
+
+func tasksGet(a *app, args []string) error {
+	fs, caseId := caseFlagSet("get")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "task-id")
+	if err != nil {
+		return err
+	}
+
+	task, err := a.client.GetCaseTask(*caseId, ids[0])
+	if err != nil {
+		return err
+	}
+	return a.render(task.Task, taskHeaders, [][]string{taskRow(task.Task)})
+}
+
+func tasksAdd(a *app, args []string) error {
+	fs, caseId := caseFlagSet("add")
+	title := fs.String("title", "", "task title (required)")
+	description := fs.String("description", "", "task description")
+	status := fs.String("status", "To do", "task status name")
+	tags := fs.String("tags", "", "comma separated tags")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	if *title == "" {
+		return fmt.Errorf("%w: -title is required", errUsage)
+	}
+
+	statusId, err := a.client.Lookup().TaskStatusID(*status)
+	if err != nil {
+		return err
+	}
+
+	task, err := a.client.AddCaseTask(*caseId, goiris.CaseTaskRequest{
+		TaskTitle:       *title,
+		TaskDescription: *description,
+		TaskStatusID:    statusId,
+		TaskTags:        *tags,
+	})
+	if err != nil {
+		return err
+	}
+	return a.render(task.Task, taskHeaders, [][]string{taskRow(task.Task)})
+}
+
+func tasksDelete(a *app, args []string) error {
+	fs, caseId := caseFlagSet("delete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "task-id")
+	if err != nil {
+		return err
+	}
+
+	return a.client.DeleteCaseTask(*caseId, ids[0])
+}
+
+var noteHeaders = []string{"id", "directory", "title"}
+
+func notesList(a *app, args []string) error {
+	fs, caseId := caseFlagSet("list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+
+	directories, err := a.client.ListNoteDirectories(*caseId)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	var walk func(path string, directories []goiris.NoteDirectory)
+	walk = func(path string, directories []goiris.NoteDirectory) {
+		for _, directory := range directories {
+			directoryPath := path + "/" + directory.Name
+			for _, note := range directory.Notes {
+				rows = append(rows, []string{itoa(note.NoteID), directoryPath, note.NoteTitle})
+			}
+			walk(directoryPath, directory.Subdirectories)
+		}
+	}
+	walk("", directories.NoteDirectories)
+
+	return a.render(directories.NoteDirectories, noteHeaders, rows)
+}
+
+func notesGet(a *app, args []string) error {
+	fs, caseId := caseFlagSet("get")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "note-id")
+	if err != nil {
+		return err
+	}
+
+	note, err := a.client.GetNote(*caseId, ids[0])
+	if err != nil {
+		return err
+	}
+
+	// the content is what people want to see, the table is only used for csv
+	if a.output == "table" {
+		fmt.Fprintf(a.stdout, "# %s\n\n%s\n", note.Note.NoteTitle, note.Note.NoteContent)
+		return nil
+	}
+	return a.render(note.Note,
+		[]string{"id", "directory", "title", "content"},
+		[][]string{{itoa(note.Note.NoteID), itoa(note.Note.DirectoryID), note.Note.NoteTitle, note.Note.NoteContent}})
+}
+
+func notesAdd(a *app, args []string) error {
+	fs, caseId := caseFlagSet("add")
+	directory := fs.Int("directory", 0, "note directory ID (required)")
+	title := fs.String("title", "", "note title (required)")
+	content := fs.String("content", "", "note content")
+	file := fs.String("file", "", "read the note content from a Markdown file, - for stdin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	if *directory == 0 || *title == "" {
+		return fmt.Errorf("%w: -directory and -title are required", errUsage)
+	}
+
+	noteContent, err := readText(*content, *file)
+	if err != nil {
+		return err
+	}
+
+	note, err := a.client.AddNote(*caseId, goiris.NoteRequest{
+		NoteTitle:   *title,
+		NoteContent: noteContent,
+		DirectoryID: *directory,
+	})
+	if err != nil {
+		return err
+	}
+	return a.render(note.Note, noteHeaders, [][]string{{itoa(note.Note.NoteID), itoa(note.Note.DirectoryID), note.Note.NoteTitle}})
+}
+
+func notesDelete(a *app, args []string) error {
+	fs, caseId := caseFlagSet("delete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "note-id")
+	if err != nil {
+		return err
+	}
+
+	return a.client.DeleteNote(*caseId, ids[0])
+}
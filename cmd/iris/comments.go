@@ -0,0 +1,138 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/b401/goiris"
+)
+
+var commentCommands = map[string]func(a *app, args []string) error{
+	"list":   commentsList,
+	"add":    commentsAdd,
+	"edit":   commentsEdit,
+	"delete": commentsDelete,
+}
+
+// commentKinds maps the object names of the command line to comment kinds
+var commentKinds = map[string]goiris.CommentKind{
+	"asset":    goiris.CommentOnAsset,
+	"ioc":      goiris.CommentOnIoc,
+	"event":    goiris.CommentOnEvent,
+	"task":     goiris.CommentOnTask,
+	"note":     goiris.CommentOnNote,
+	"evidence": goiris.CommentOnEvidence,
+}
+
+var commentHeaders = []string{"id", "date", "user", "text"}
+
+func commentRow(comment goiris.Comment) []string {
+	return []string{itoa(comment.CommentID), comment.CommentDate, comment.Name, comment.CommentText}
+}
+
+// commentTarget reads "<kind> <object-id>" and the given trailing IDs from the arguments
+func commentTarget(fs *flag.FlagSet, names ...string) (goiris.CommentKind, []int, error) {
+	if fs.NArg() < 1 {
+		return "", nil, fmt.Errorf("%w: iris comments %s [flags] asset|ioc|event|task|note|evidence <object-id> %s", errUsage, fs.Name(), joinArgs(names))
+	}
+	kind, ok := commentKinds[fs.Arg(0)]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: unknown object %q", errUsage, fs.Arg(0))
+	}
+
+	objects := newFlagSet(fs.Name())
+	if err := objects.Parse(fs.Args()[1:]); err != nil {
+		return "", nil, err
+	}
+	ids, err := positionalIDs(objects, append([]string{"object-id"}, names...)...)
+	return kind, ids, err
+}
+
+func commentsList(a *app, args []string) error {
+	fs, caseId := caseFlagSet("list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	kind, ids, err := commentTarget(fs)
+	if err != nil {
+		return err
+	}
+
+	comments, err := a.client.ListComments(kind, *caseId, ids[0])
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, comment := range comments.Comments {
+		rows = append(rows, commentRow(comment))
+	}
+	return a.render(comments.Comments, commentHeaders, rows)
+}
+
+func commentsAdd(a *app, args []string) error {
+	fs, caseId := caseFlagSet("add")
+	text := fs.String("text", "", "comment text, markdown (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	if *text == "" {
+		return fmt.Errorf("%w: -text is required", errUsage)
+	}
+	kind, ids, err := commentTarget(fs)
+	if err != nil {
+		return err
+	}
+
+	comment, err := a.client.AddComment(kind, *caseId, ids[0], *text)
+	if err != nil {
+		return err
+	}
+	return a.render(comment.Comment, commentHeaders, [][]string{commentRow(comment.Comment)})
+}
+
+func commentsEdit(a *app, args []string) error {
+	fs, caseId := caseFlagSet("edit")
+	text := fs.String("text", "", "new comment text, markdown (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	if *text == "" {
+		return fmt.Errorf("%w: -text is required", errUsage)
+	}
+	kind, ids, err := commentTarget(fs, "comment-id")
+	if err != nil {
+		return err
+	}
+
+	comment, err := a.client.UpdateComment(kind, *caseId, ids[0], ids[1], *text)
+	if err != nil {
+		return err
+	}
+	return a.render(comment.Comment, commentHeaders, [][]string{commentRow(comment.Comment)})
+}
+
+func commentsDelete(a *app, args []string) error {
+	fs, caseId := caseFlagSet("delete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	kind, ids, err := commentTarget(fs, "comment-id")
+	if err != nil {
+		return err
+	}
+
+	return a.client.DeleteComment(kind, *caseId, ids[0], ids[1])
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/b401/goiris"
+	"gopkg.in/yaml.v3"
+)
+
+// profileFile is the layout of the profile file:
+//
+//	default: prod
+//	profiles:
+//	  prod:
+//	    url: https://iris.example.com
+//	    token: <api key>
+//	  lab:
+//	    url: https://iris.lab
+//	    token: <api key>
+//	    insecure: true
+//	    timeout: 30s
+type profileFile struct {
+	Default  string             `yaml:"default"`
+	Profiles map[string]profile `yaml:"profiles"`
+}
+
+type profile struct {
+	URL      string        `yaml:"url"`
+	Token    string        `yaml:"token"`
+	Insecure bool          `yaml:"insecure"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+func defaultConfigPath() string {
+	if path := os.Getenv("IRIS_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "iris", "config.yaml")
+}
+
+// loadProfile reads the named profile from the profile file and applies the environment overrides.
+// A missing profile file is fine as long as the environment provides url and token.
+func loadProfile(path, name string) (*profile, error) {
+	explicitPath := path != ""
+	if path == "" {
+		path = defaultConfigPath()
+	}
+	if name == "" {
+		name = os.Getenv("IRIS_PROFILE")
+	}
+
+	var p profile
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		var file profileFile
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if name == "" {
+			name = file.Default
+		}
+		if name != "" {
+			found, ok := file.Profiles[name]
+			if !ok {
+				return nil, fmt.Errorf("%s: unknown profile %q", path, name)
+			}
+			p = found
+		}
+	case errors.Is(err, os.ErrNotExist) && !explicitPath && name == "":
+	default:
+		return nil, err
+	}
+
+	if url := os.Getenv("IRIS_URL"); url != "" {
+		p.URL = url
+	}
+	if token := os.Getenv("IRIS_TOKEN"); token != "" {
+		p.Token = token
+	}
+	if insecure := os.Getenv("IRIS_INSECURE"); insecure != "" {
+		value, err := strconv.ParseBool(insecure)
+		if err != nil {
+			return nil, fmt.Errorf("IRIS_INSECURE: %w", err)
+		}
+		p.Insecure = value
+	}
+
+	if p.URL == "" || p.Token == "" {
+		return nil, fmt.Errorf("no connection configured: create %s or set IRIS_URL and IRIS_TOKEN", path)
+	}
+
+	return &p, nil
+}
+
+func (p *profile) client() *goiris.APIClient {
+	conf := goiris.GetInstance()
+	conf.BaseUrl = p.URL
+	conf.AuthToken = p.Token
+
+	return &goiris.APIClient{
+		AuthStrategy: &goiris.ApiKeyAuth{ApiKey: conf.AuthToken},
+		BaseURL:      conf.BaseUrl,
+		Client:       *goiris.NewConfiguredHttpClient(goiris.ClientConfig{IgnoreTLS: p.Insecure, Timeout: p.Timeout}),
+	}
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/b401/goiris"
+)
+
+var customerCommands = map[string]func(a *app, args []string) error{
+	"list":   customersList,
+	"get":    customersGet,
+	"add":    customersAdd,
+	"update": customersUpdate,
+	"delete": customersDelete,
+	"apply":  customersApply,
+}
+
+var contactCommands = map[string]func(a *app, args []string) error{
+	"list":   contactsList,
+	"add":    contactsAdd,
+	"update": contactsUpdate,
+	"delete": contactsDelete,
+}
+
+var customerHeaders = []string{"id", "name", "sla", "uuid", "description"}
+
+func customerRow(customer goiris.Customer) []string {
+	return []string{itoa(customer.CustomerID), customer.CustomerName, customer.CustomerSLA, customer.CustomerUUID, customer.CustomerDescription}
+}
+
+func customersList(a *app, args []string) error {
+	fs := newFlagSet("list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	customers, err := a.client.GetCustomers()
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, customer := range customers.Customers {
+		rows = append(rows, customerRow(customer))
+	}
+	return a.render(customers.Customers, customerHeaders, rows)
+}
+
+func customersGet(a *app, args []string) error {
+	fs := newFlagSet("get")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "customer-id")
+	if err != nil {
+		return err
+	}
+
+	customer, err := a.client.GetCustomer(ids[0])
+	if err != nil {
+		return err
+	}
+	return a.render(customer.Customer, customerHeaders, [][]string{customerRow(customer.Customer)})
+}
+
+func customersAdd(a *app, args []string) error {
+	fs := newFlagSet("add")
+	name := fs.String("name", "", "customer name (required)")
+	description := fs.String("description", "", "customer description")
+	sla := fs.String("sla", "", "customer SLA")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return fmt.Errorf("%w: -name is required", errUsage)
+	}
+
+	customer, err := a.client.AddCustomer(goiris.AddCustomerRequest{
+		CustomerName:        *name,
+		CustomerDescription: *description,
+		CustomerSLA:         *sla,
+		CustomAttributes:    goiris.AttributeValues{},
+	})
+	if err != nil {
+		return err
+	}
+
+	created := customer.Customer
+	return a.render(created,
+		customerHeaders,
+		[][]string{{itoa(created.CustomerID), created.CustomerName, created.CustomerSLA, created.ClientUUID, created.CustomerDescription}})
+}
+
+func customersUpdate(a *app, args []string) error {
+	fs := newFlagSet("update")
+	name := fs.String("name", "", "new customer name")
+	description := fs.String("description", "", "new customer description")
+	sla := fs.String("sla", "", "new customer SLA")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "customer-id")
+	if err != nil {
+		return err
+	}
+
+	current, err := a.client.GetCustomer(ids[0])
+	if err != nil {
+		return err
+	}
+
+	update := goiris.UpdateCustomerRequest{
+		CustomerName:        current.CustomerName,
+		CustomerDescription: current.CustomerDescription,
+		CustomerSLA:         current.CustomerSLA,
+		CustomAttributes:    current.CustomAttributes.Values(),
+	}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			update.CustomerName = *name
+		case "description":
+			update.CustomerDescription = *description
+		case "sla":
+			update.CustomerSLA = *sla
+		}
+	})
+
+	customer, err := a.client.UpdateCustomer(ids[0], update)
+	if err != nil {
+		return err
+	}
+	return a.render(customer.Customer, customerHeaders, [][]string{customerRow(customer.Customer)})
+}
+
+func customersDelete(a *app, args []string) error {
+	fs := newFlagSet("delete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "customer-id")
+	if err != nil {
+		return err
+	}
+
+	return a.client.DeleteCustomer(ids[0])
+}
+
+func customersApply(a *app, args []string) error {
+	fs := newFlagSet("apply")
+	file := fs.String("f", "", "desired state file, .json or .yaml (required)")
+	dryRun := fs.Bool("dry-run", false, "only print the planned changes")
+	pruneContacts := fs.Bool("prune-contacts", false, "delete contacts of managed customers missing from the file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("%w: -f is required", errUsage)
+	}
+
+	state, err := goiris.LoadCustomerState(*file)
+	if err != nil {
+		return err
+	}
+
+	plan, err := a.client.PlanCustomers(*state, goiris.CustomerPlanOptions{PruneContacts: *pruneContacts})
+	if err != nil {
+		return err
+	}
+
+	if a.output == "table" {
+		fmt.Fprint(a.stdout, plan)
+	} else {
+		var rows [][]string
+		for _, change := range plan.Changes {
+			rows = append(rows, []string{change.Action, change.CustomerName, change.ContactName, strings.Join(change.Diff, "; ")})
+		}
+		if err := a.render(plan.Changes, []string{"action", "customer", "contact", "diff"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if *dryRun || plan.Empty() {
+		return nil
+	}
+	return a.client.ApplyCustomerPlan(plan)
+}
+
+var contactHeaders = []string{"id", "name", "role", "email", "mobile", "work phone"}
+
+func contactRow(contact goiris.Contact) []string {
+	return []string{itoa(contact.ID), contact.ContactName, contact.ContactRole, contact.ContactEmail, contact.ContactMobilePhone, contact.ContactWorkPhone}
+}
+
+func contactsList(a *app, args []string) error {
+	fs := newFlagSet("list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "customer-id")
+	if err != nil {
+		return err
+	}
+
+	customer, err := a.client.GetCustomer(ids[0])
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, contact := range customer.Contacts {
+		rows = append(rows, contactRow(contact))
+	}
+	return a.render(customer.Contacts, contactHeaders, rows)
+}
+
+type contactFlags struct {
+	name, role, email, mobile, phone, note *string
+}
+
+func newContactFlags(fs *flag.FlagSet) contactFlags {
+	return contactFlags{
+		name:   fs.String("name", "", "contact name"),
+		role:   fs.String("role", "", "contact role"),
+		email:  fs.String("email", "", "contact email"),
+		mobile: fs.String("mobile", "", "contact mobile phone"),
+		phone:  fs.String("phone", "", "contact work phone"),
+		note:   fs.String("note", "", "contact note"),
+	}
+}
+
+func contactsAdd(a *app, args []string) error {
+	fs := newFlagSet("add")
+	flags := newContactFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "customer-id")
+	if err != nil {
+		return err
+	}
+	if *flags.name == "" {
+		return fmt.Errorf("%w: -name is required", errUsage)
+	}
+
+	contact, err := a.client.AddCustomerContact(ids[0], goiris.AddCustomerContactRequest{
+		ContactName:        *flags.name,
+		ContactRole:        *flags.role,
+		ContactEmail:       *flags.email,
+		ContactMobilePhone: *flags.mobile,
+		ContactPhone:       *flags.phone,
+		ContactNote:        *flags.note,
+		CustomAttributes:   goiris.AttributeValues{},
+	})
+	if err != nil {
+		return err
+	}
+	return a.render(contact.Contact, contactHeaders, [][]string{contactRow(contact.Contact)})
+}
+
+func contactsUpdate(a *app, args []string) error {
+	fs := newFlagSet("update")
+	newContactFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "customer-id", "contact-id")
+	if err != nil {
+		return err
+	}
+
+	customer, err := a.client.GetCustomer(ids[0])
+	if err != nil {
+		return err
+	}
+
+	var update *goiris.UpdateContactRequest
+	for _, contact := range customer.Contacts {
+		if contact.ID == ids[1] {
+			update = &goiris.UpdateContactRequest{
+				ContactName:        contact.ContactName,
+				ContactRole:        contact.ContactRole,
+				ContactEmail:       contact.ContactEmail,
+				ContactMobilePhone: contact.ContactMobilePhone,
+				ContactWorkPhone:   contact.ContactWorkPhone,
+				ContactNote:        contact.ContactNote,
+				CustomAttributes:   contact.CustomAttributes.Values(),
+			}
+		}
+	}
+	if update == nil {
+		return fmt.Errorf("customer %d has no contact %d", ids[0], ids[1])
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		value := f.Value.String()
+		switch f.Name {
+		case "name":
+			update.ContactName = value
+		case "role":
+			update.ContactRole = value
+		case "email":
+			update.ContactEmail = value
+		case "mobile":
+			update.ContactMobilePhone = value
+		case "phone":
+			update.ContactWorkPhone = value
+		case "note":
+			update.ContactNote = value
+		}
+	})
+
+	contact, err := a.client.UpdateCustomerContact(ids[0], ids[1], *update)
+	if err != nil {
+		return err
+	}
+	return a.render(contact.Contact, contactHeaders, [][]string{contactRow(contact.Contact)})
+}
+
+func contactsDelete(a *app, args []string) error {
+	fs := newFlagSet("delete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "customer-id", "contact-id")
+	if err != nil {
+		return err
+	}
+
+	return a.client.DeleteCustomerContact(ids[0], ids[1])
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"github.com/b401/goiris"
+)
+
+var dashboardCommands = map[string]func(a *app, args []string) error{
+	"cases":   dashboardCases,
+	"tasks":   dashboardTasks,
+	"summary": dashboardSummary,
+}
+
+func dashboardCases(a *app, args []string) error {
+	fs := newFlagSet("cases")
+	closed := fs.Bool("closed", false, "include closed cases")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cases, err := a.client.ListUserCases(*closed)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, c := range cases.Cases {
+		rows = append(rows, []string{itoa(c.CaseID), c.Name, c.Client.CustomerName, c.State.StateName, c.OpenDate})
+	}
+	return a.render(cases.Cases, []string{"id", "name", "customer", "state", "opened"}, rows)
+}
+
+func dashboardTasks(a *app, args []string) error {
+	fs := newFlagSet("tasks")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	tasks, err := a.client.ListUserTasks()
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, task := range tasks.Data.Tasks {
+		rows = append(rows, []string{itoa(task.TaskID), itoa(task.TaskCaseID), task.TaskCase, task.TaskTitle, task.StatusName})
+	}
+	return a.render(tasks.Data.Tasks, []string{"id", "case", "case name", "title", "status"}, rows)
+}
+
+// dashboardSummary prints the counts a standup needs, the json output contains the full dashboard
+func dashboardSummary(a *app, args []string) error {
+	fs := newFlagSet("summary")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	dashboard, err := a.client.GetDashboard()
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{
+		{"open cases", itoa(len(dashboard.Cases))},
+		{"closed cases (chart period)", itoa(dashboard.Charts.ClosedCases.Total())},
+	}
+	rows = append(rows, statusRows("my tasks", dashboard.TaskCounts())...)
+	rows = append(rows, statusRows("global tasks", dashboard.GlobalTaskCounts())...)
+	return a.render(dashboard, []string{"metric", "count"}, rows)
+}
+
+func statusRows(prefix string, counts []goiris.StatusCount) [][]string {
+	var rows [][]string
+	for _, count := range counts {
+		rows = append(rows, []string{prefix + ": " + count.Status, itoa(count.Count)})
+	}
+	return rows
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/b401/goiris"
+)
+
+var globalTaskCommands = map[string]func(a *app, args []string) error{
+	"list":   globalTasksList,
+	"get":    globalTasksGet,
+	"add":    globalTasksAdd,
+	"update": globalTasksUpdate,
+	"delete": globalTasksDelete,
+}
+
+var globalTaskHeaders = []string{"id", "title", "status", "assignee", "tags"}
+
+func globalTaskRow(task goiris.GlobalTask) []string {
+	return []string{itoa(task.TaskID), task.TaskTitle, task.StatusName, task.UserName, task.TaskTags}
+}
+
+func globalTasksList(a *app, args []string) error {
+	fs := newFlagSet("list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	tasks, err := a.client.ListGlobalTasks()
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, task := range tasks.Data.Tasks {
+		rows = append(rows, globalTaskRow(task))
+	}
+	return a.render(tasks.Data.Tasks, globalTaskHeaders, rows)
+}
+
+func globalTasksGet(a *app, args []string) error {
+	fs := newFlagSet("get")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "task-id")
+	if err != nil {
+		return err
+	}
+
+	task, err := a.client.GetGlobalTask(ids[0])
+	if err != nil {
+		return err
+	}
+	return a.render(task, globalTaskHeaders, [][]string{globalTaskRow(*task)})
+}
+
+func globalTasksAdd(a *app, args []string) error {
+	fs := newFlagSet("add")
+	title := fs.String("title", "", "task title (required)")
+	description := fs.String("description", "", "task description")
+	status := fs.String("status", "To do", "task status name")
+	assignee := fs.String("assignee", "", "login of the assigned user (required)")
+	tags := fs.String("tags", "", "comma separated tags")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *title == "" || *assignee == "" {
+		return fmt.Errorf("%w: -title and -assignee are required", errUsage)
+	}
+
+	statusId, err := a.client.Lookup().TaskStatusID(*status)
+	if err != nil {
+		return err
+	}
+	assigneeId, err := a.client.Lookup().UserID(*assignee)
+	if err != nil {
+		return err
+	}
+
+	task, err := a.client.AddGlobalTask(goiris.GlobalTaskRequest{
+		TaskTitle:       *title,
+		TaskDescription: *description,
+		TaskStatusID:    statusId,
+		TaskAssigneeID:  assigneeId,
+		TaskTags:        *tags,
+	})
+	if err != nil {
+		return err
+	}
+	return a.render(task.Task, globalTaskHeaders, [][]string{globalTaskRow(task.Task)})
+}
+
+func globalTasksUpdate(a *app, args []string) error {
+	fs := newFlagSet("update")
+	title := fs.String("title", "", "new task title")
+	description := fs.String("description", "", "new task description")
+	status := fs.String("status", "", "new task status name")
+	assignee := fs.String("assignee", "", "login of the newly assigned user")
+	tags := fs.String("tags", "", "new comma separated tags")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "task-id")
+	if err != nil {
+		return err
+	}
+
+	current, err := a.client.GetGlobalTask(ids[0])
+	if err != nil {
+		return err
+	}
+	request := current.Request()
+	if *title != "" {
+		request.TaskTitle = *title
+	}
+	if *description != "" {
+		request.TaskDescription = *description
+	}
+	if *tags != "" {
+		request.TaskTags = *tags
+	}
+	if *status != "" {
+		if request.TaskStatusID, err = a.client.Lookup().TaskStatusID(*status); err != nil {
+			return err
+		}
+	}
+	if *assignee != "" {
+		if request.TaskAssigneeID, err = a.client.Lookup().UserID(*assignee); err != nil {
+			return err
+		}
+	}
+
+	task, err := a.client.UpdateGlobalTask(ids[0], request)
+	if err != nil {
+		return err
+	}
+	return a.render(task.Task, globalTaskHeaders, [][]string{globalTaskRow(task.Task)})
+}
+
+func globalTasksDelete(a *app, args []string) error {
+	fs := newFlagSet("delete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "task-id")
+	if err != nil {
+		return err
+	}
+
+	return a.client.DeleteGlobalTask(ids[0])
+}
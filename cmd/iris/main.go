@@ -0,0 +1,200 @@
+// Command iris is a command-line client for DFIR-IRIS built on the goiris library.
+//
+// Usage:
+//
+//	iris [-profile name] [-o table|json|csv] <command> [subcommand] [flags] [args]
+//
+// Connection settings are read from the profile file (see -config, $IRIS_CONFIG) and can be
+// overridden with $IRIS_URL, $IRIS_TOKEN and $IRIS_INSECURE. Build a static binary with
+//
+//	CGO_ENABLED=0 go build ./cmd/iris
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/b401/goiris"
+)
+
+type app struct {
+	client *goiris.APIClient
+	output string
+	stdout io.Writer
+}
+
+type command struct {
+	usage       string
+	subcommands map[string]func(app *app, args []string) error
+	run         func(app *app, args []string) error
+}
+
+var errUsage = errors.New("usage")
+
+var commands = map[string]command{
+	"ping":         {usage: "ping", run: runPing},
+	"activities":   {usage: "activities list|export [-case ID] [-from T] [-to T]", subcommands: activityCommands},
+	"version":      {usage: "version", run: runVersion},
+	"customers":    {usage: "customers list|get|add|update|delete|apply", subcommands: customerCommands},
+	"contacts":     {usage: "contacts list|add|update|delete", subcommands: contactCommands},
+	"cases":        {usage: "cases list|get|add|close|reopen|delete|export|import|export-misp|import-misp", subcommands: caseCommands},
+	"assets":       {usage: "assets list|get|add|delete -case ID", subcommands: assetCommands},
+	"iocs":         {usage: "iocs list|get|add|delete|export-stix|import-stix -case ID", subcommands: iocCommands},
+	"tasks":        {usage: "tasks list|get|add|delete -case ID", subcommands: taskCommands},
+	"global-tasks": {usage: "global-tasks list|get|add|update|delete", subcommands: globalTaskCommands},
+	"dashboard":    {usage: "dashboard cases|tasks|summary", subcommands: dashboardCommands},
+	"notes":        {usage: "notes list|get|add|delete -case ID", subcommands: noteCommands},
+	"access":       {usage: "access list|grant|revoke|check -case ID [-user login|-group name]", subcommands: accessCommands},
+	"summary":      {usage: "summary get|set|section -case ID", subcommands: summaryCommands},
+	"comments":     {usage: "comments list|add|edit|delete -case ID <object> <object-id> [comment-id]", subcommands: commentCommands},
+	"timeline":     {usage: "timeline list|import|export -case ID", subcommands: timelineCommands},
+	"templates":    {usage: "templates list|get|export|import", subcommands: templateCommands},
+	"search":       {usage: "search iocs|notes <pattern> | search seen <value>", subcommands: searchCommands},
+	"watch":        {usage: "watch [-kinds case,alert,activity] [-interval 1m] [-state dir]", run: runWatch},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: iris [-config file] [-profile name] [-o table|json|csv] <command> [subcommand] [flags] [args]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", commands[name].usage)
+	}
+}
+
+func main() {
+	configPath := flag.String("config", "", "profile file (default $IRIS_CONFIG or <user config dir>/iris/config.yaml)")
+	profileName := flag.String("profile", "", "profile to use (default $IRIS_PROFILE or the default profile of the file)")
+	output := flag.String("o", "table", "output format: table, json or csv")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch *output {
+	case "table", "json", "csv":
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output format: %s\n", *output)
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	profile, err := loadProfile(*configPath, *profileName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	a := &app{
+		client: profile.client(),
+		output: *output,
+		stdout: os.Stdout,
+	}
+
+	args := flag.Args()[1:]
+	run := cmd.run
+	if cmd.subcommands != nil {
+		if len(args) == 0 {
+			fmt.Fprintf(os.Stderr, "usage: iris %s\n", cmd.usage)
+			os.Exit(2)
+		}
+		run, ok = cmd.subcommands[args[0]]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown subcommand: %s\nusage: iris %s\n", args[0], cmd.usage)
+			os.Exit(2)
+		}
+		args = args[1:]
+	}
+
+	if err := run(a, args); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runPing(a *app, args []string) error {
+	pong, err := a.client.Ping()
+	if err != nil {
+		return err
+	}
+
+	return a.render(pong, []string{"status", "message"}, [][]string{{pong.Status, pong.Message}})
+}
+
+func runVersion(a *app, args []string) error {
+	version, err := a.client.GetAPIVersion()
+	if err != nil {
+		return err
+	}
+
+	return a.render(version.Data,
+		[]string{"iris", "api", "api min"},
+		[][]string{{version.Data.IrisCurrent, version.Data.ApiCurrent, version.Data.ApiMin}})
+}
+
+// newFlagSet returns a flag set that reports errors instead of exiting
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// positionalIDs parses exactly len(names) integer arguments
+func positionalIDs(fs *flag.FlagSet, names ...string) ([]int, error) {
+	if fs.NArg() != len(names) {
+		return nil, fmt.Errorf("%w: iris ... %s [flags] %s", errUsage, fs.Name(), joinArgs(names))
+	}
+
+	ids := make([]int, len(names))
+	for i, name := range names {
+		id, err := strconv.Atoi(fs.Arg(i))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s must be a number, got %q", errUsage, name, fs.Arg(i))
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func joinArgs(names []string) string {
+	s := ""
+	for i, name := range names {
+		if i > 0 {
+			s += " "
+		}
+		s += "<" + name + ">"
+	}
+	return s
+}
+
+// requireCase checks that the -case flag was given
+func requireCase(caseId int) error {
+	if caseId <= 0 {
+		return fmt.Errorf("%w: -case is required", errUsage)
+	}
+	return nil
+}
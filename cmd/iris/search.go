@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+var searchCommands = map[string]func(a *app, args []string) error{
+	"iocs":  searchIocs,
+	"notes": searchNotes,
+	"seen":  searchSeen,
+}
+
+func searchIocs(a *app, args []string) error {
+	fs := newFlagSet("iocs")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	pattern, err := searchArgument(fs.Args(), "pattern")
+	if err != nil {
+		return err
+	}
+
+	response, err := a.client.SearchIocs(pattern)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, hit := range response.Hits {
+		rows = append(rows, []string{hit.IocValue, hit.TypeName, hit.TlpName, itoa(hit.CaseID), hit.CaseName, hit.CustomerName})
+	}
+	return a.render(response.Hits, []string{"value", "type", "tlp", "case", "case name", "customer"}, rows)
+}
+
+func searchNotes(a *app, args []string) error {
+	fs := newFlagSet("notes")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	pattern, err := searchArgument(fs.Args(), "pattern")
+	if err != nil {
+		return err
+	}
+
+	response, err := a.client.SearchNotes(pattern)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, hit := range response.Hits {
+		rows = append(rows, []string{itoa(hit.NoteID), hit.NoteTitle, itoa(hit.CaseID), hit.CaseName, hit.CustomerName})
+	}
+	return a.render(response.Hits, []string{"id", "title", "case", "case name", "customer"}, rows)
+}
+
+func searchSeen(a *app, args []string) error {
+	fs := newFlagSet("seen")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	value, err := searchArgument(fs.Args(), "value")
+	if err != nil {
+		return err
+	}
+
+	cases, err := a.client.SeenIOC(value)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, c := range cases {
+		rows = append(rows, []string{itoa(c.CaseID), c.CaseName, c.CustomerName})
+	}
+	return a.render(cases, []string{"case", "case name", "customer"}, rows)
+}
+
+// searchArgument joins the positional arguments, so patterns with spaces need no quoting
+func searchArgument(args []string, name string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("%w: missing %s", errUsage, name)
+	}
+	return strings.Join(args, " "), nil
+}
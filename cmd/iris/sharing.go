@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/b401/goiris"
+)
+
+// writeOutput writes data as indented JSON to file, or stdout if file is empty or "-"
+func writeOutput(a *app, file string, data interface{}) error {
+	var w io.Writer = a.stdout
+	if file != "" && file != "-" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+func iocsExportStix(a *app, args []string) error {
+	fs, caseId := caseFlagSet("export-stix")
+	out := fs.String("out", "", "bundle file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+
+	bundle, skipped, err := a.client.ExportStixBundle(*caseId)
+	if err != nil {
+		return err
+	}
+	for _, message := range skipped {
+		fmt.Fprintln(os.Stderr, "skipped:", message)
+	}
+	return writeOutput(a, *out, bundle)
+}
+
+func iocsImportStix(a *app, args []string) error {
+	fs, caseId := caseFlagSet("import-stix")
+	file := fs.String("f", "", "STIX 2.1 bundle (required)")
+	tlp := fs.String("tlp", "", "TLP of objects without TLP marking (default amber)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("%w: -f is required", errUsage)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	bundle, err := goiris.ParseStixBundle(f)
+	if err != nil {
+		return err
+	}
+
+	result, err := a.client.ImportStixBundle(*caseId, bundle, goiris.StixImportOptions{DefaultTlp: *tlp})
+	if err != nil {
+		return err
+	}
+	for _, message := range result.Skipped {
+		fmt.Fprintln(os.Stderr, "skipped:", message)
+	}
+
+	var rows [][]string
+	for _, ioc := range result.Created {
+		rows = append(rows, iocRow(ioc))
+	}
+	return a.render(result.Created, iocHeaders, rows)
+}
+
+func casesExportMisp(a *app, args []string) error {
+	fs := newFlagSet("export-misp")
+	out := fs.String("out", "", "event file (default case-<id>.misp.json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "case-id")
+	if err != nil {
+		return err
+	}
+
+	event, err := a.client.ExportMispEvent(ids[0])
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		*out = fmt.Sprintf("case-%d.misp.json", ids[0])
+	}
+	if err := goiris.WriteMispEventFile(*out, *event); err != nil {
+		return err
+	}
+
+	return a.render(event, []string{"file", "attributes", "objects"},
+		[][]string{{*out, itoa(len(event.Attributes)), itoa(len(event.Objects))}})
+}
+
+func casesImportMisp(a *app, args []string) error {
+	fs := newFlagSet("import-misp")
+	file := fs.String("f", "", "MISP event JSON file (required)")
+	tlp := fs.String("tlp", "", "TLP of attributes without tlp tag (default amber)")
+	assetType := fs.String("asset-type", "", "asset type of devices whose device-type is unknown")
+	skipTags := fs.Bool("skip-tags", false, "do not add event tags and galaxies to the case tags")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "case-id")
+	if err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("%w: -f is required", errUsage)
+	}
+
+	event, err := goiris.LoadMispEventFile(*file)
+	if err != nil {
+		return err
+	}
+	result, err := a.client.ImportMispEvent(ids[0], event, goiris.MispImportOptions{
+		DefaultTlp:       *tlp,
+		DefaultAssetType: *assetType,
+		SkipTags:         *skipTags,
+	})
+	if err != nil {
+		return err
+	}
+	for _, message := range result.Skipped {
+		fmt.Fprintln(os.Stderr, "skipped:", message)
+	}
+
+	var rows [][]string
+	for _, ioc := range result.Iocs {
+		rows = append(rows, []string{"ioc", itoa(ioc.IocID), ioc.IocValue})
+	}
+	for _, asset := range result.Assets {
+		rows = append(rows, []string{"asset", itoa(asset.AssetID), asset.AssetName})
+	}
+	for _, tag := range result.Tags {
+		rows = append(rows, []string{"tag", "", tag})
+	}
+	return a.render(result, []string{"created", "id", "value"}, rows)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/b401/goiris"
+)
+
+var summaryCommands = map[string]func(a *app, args []string) error{
+	"get":     summaryGet,
+	"set":     summarySet,
+	"section": summarySection,
+}
+
+// readText returns the content of file, - for stdin, or text if file is empty
+func readText(text, file string) (string, error) {
+	if file == "" {
+		return text, nil
+	}
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	return string(data), err
+}
+
+// summaryGet prints the Markdown of the summary or of one of its sections, the json output adds the checksum
+func summaryGet(a *app, args []string) error {
+	fs, caseId := caseFlagSet("get")
+	title := fs.String("section", "", "only print the section with this heading")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+
+	summary, err := a.client.GetCaseSummary(*caseId)
+	if err != nil {
+		return err
+	}
+
+	text := summary.Summary.CaseDescription
+	if *title != "" {
+		var ok bool
+		if text, ok = goiris.MarkdownSection(text, *title); !ok {
+			return fmt.Errorf("%w: section %q", goiris.ErrLookupNotFound, *title)
+		}
+	}
+	if a.output == "json" {
+		return a.render(map[string]interface{}{"summary": text, "checksum": summary.Summary.Checksum()}, nil, nil)
+	}
+	_, err = fmt.Fprintln(a.stdout, strings.TrimRight(text, "\n"))
+	return err
+}
+
+func summarySet(a *app, args []string) error {
+	fs, caseId := caseFlagSet("set")
+	file := fs.String("f", "", "Markdown file, - for stdin (required)")
+	checksum := fs.Uint("checksum", 0, "only write if the summary still has this checksum, from summary get")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("%w: -f is required", errUsage)
+	}
+	text, err := readText("", *file)
+	if err != nil {
+		return err
+	}
+
+	// a checksum of 0 is valid, only the presence of the flag makes the write conditional
+	conditional := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "checksum" {
+			conditional = true
+		}
+	})
+	if conditional {
+		if *checksum > math.MaxUint32 {
+			return fmt.Errorf("%w: -checksum is a CRC32", errUsage)
+		}
+		return a.client.UpdateCaseSummary(*caseId, uint32(*checksum), text)
+	}
+	return a.client.SetCaseSummary(*caseId, text)
+}
+
+// summarySection replaces or appends to one section, other sections keep concurrent edits of analysts
+func summarySection(a *app, args []string) error {
+	fs, caseId := caseFlagSet("section")
+	title := fs.String("title", "", "heading of the section (required)")
+	text := fs.String("text", "", "section text, Markdown")
+	file := fs.String("f", "", "read the section text from a Markdown file, - for stdin")
+	appendText := fs.Bool("append", false, "append to the section instead of replacing it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	if *title == "" {
+		return fmt.Errorf("%w: -title is required", errUsage)
+	}
+	body, err := readText(*text, *file)
+	if err != nil {
+		return err
+	}
+
+	return a.client.EditCaseSummary(*caseId, 3, func(summary string) (string, error) {
+		if *appendText {
+			return goiris.AppendMarkdownSection(summary, *title, body), nil
+		}
+		return goiris.ReplaceMarkdownSection(summary, *title, body), nil
+	})
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/b401/goiris"
+)
+
+var templateCommands = map[string]func(a *app, args []string) error{
+	"list":   templatesList,
+	"get":    templatesGet,
+	"export": templatesExport,
+	"import": templatesImport,
+}
+
+var templateHeaders = []string{"id", "name", "display name", "author", "classification", "description"}
+
+func templateRow(template goiris.CaseTemplate) []string {
+	return []string{itoa(template.ID), template.Name, template.DisplayName, template.Author, template.Classification, template.Description}
+}
+
+func templatesList(a *app, args []string) error {
+	fs := newFlagSet("list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	templates, err := a.client.ListCaseTemplates()
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, template := range templates.CaseTemplates {
+		rows = append(rows, templateRow(template))
+	}
+	return a.render(templates.CaseTemplates, templateHeaders, rows)
+}
+
+func templatesGet(a *app, args []string) error {
+	fs := newFlagSet("get")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := positionalIDs(fs, "template-id")
+	if err != nil {
+		return err
+	}
+
+	template, err := a.client.GetCaseTemplate(ids[0])
+	if err != nil {
+		return err
+	}
+	return a.render(template.CaseTemplate, templateHeaders, [][]string{templateRow(template.CaseTemplate)})
+}
+
+func templatesExport(a *app, args []string) error {
+	fs := newFlagSet("export")
+	dir := fs.String("dir", ".", "target directory")
+	format := fs.String("format", "yaml", "file format: json or yaml")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	files, err := a.client.ExportCaseTemplates(*dir, goiris.CaseTemplateFileFormat(*format))
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, file := range files {
+		rows = append(rows, []string{file})
+	}
+	return a.render(files, []string{"file"}, rows)
+}
+
+func templatesImport(a *app, args []string) error {
+	fs := newFlagSet("import")
+	dir := fs.String("dir", ".", "source directory")
+	dryRun := fs.Bool("dry-run", false, "only show what would change")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := a.client.ImportCaseTemplates(*dir, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, result := range report.Results {
+		detail := strings.Join(result.Diff, "; ")
+		if result.Err != nil {
+			detail = result.Err.Error()
+		}
+		rows = append(rows, []string{result.File, result.Name, result.Action, detail})
+	}
+	if err := a.render(report.Results, []string{"file", "name", "action", "details"}, rows); err != nil {
+		return err
+	}
+
+	if err := report.Err(); err != nil {
+		return fmt.Errorf("some templates were not imported:\n%w", err)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/b401/goiris"
+)
+
+var timelineCommands = map[string]func(a *app, args []string) error{
+	"list":   timelineList,
+	"import": timelineImport,
+	"export": timelineExport,
+}
+
+var timelineHeaders = []string{"id", "date", "tz", "title", "category", "source", "tags"}
+
+func timelineRow(event goiris.TimelineEvent) []string {
+	return []string{itoa(event.EventID), event.EventDate, event.EventTz, event.EventTitle, event.CategoryName, event.EventSource, event.EventTags}
+}
+
+func timelineList(a *app, args []string) error {
+	fs, caseId := caseFlagSet("list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+
+	events, err := a.client.ListTimelineEvents(*caseId)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, event := range events.Data.Events {
+		rows = append(rows, timelineRow(event))
+	}
+	return a.render(events.Data.Events, timelineHeaders, rows)
+}
+
+func timelineExport(a *app, args []string) error {
+	fs, caseId := caseFlagSet("export")
+	out := fs.String("out", "", "output file (default stdout)")
+	format := fs.String("format", "csv", "file format: csv, json or timesketch")
+	tz := fs.String("tz", "", "timezone the times are converted to (default the timezone of each event)")
+	layout := fs.String("layout", "", "Go layout of the exported times (default "+goiris.TimelineExportDateFormat+")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+
+	options := goiris.TimelineExportOptions{TimeLayout: *layout}
+	if *tz != "" {
+		location, err := time.LoadLocation(*tz)
+		if err != nil {
+			return err
+		}
+		options.Location = location
+	}
+
+	var w io.Writer = a.stdout
+	if *out != "" && *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	count, err := a.client.ExportTimeline(*caseId, w, goiris.TimelineExportFormat(*format), options)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d events exported\n", count)
+	return nil
+}
+
+func timelineImport(a *app, args []string) error {
+	fs, caseId := caseFlagSet("import")
+	file := fs.String("f", "", "timeline file, - for stdin (required)")
+	format := fs.String("format", "csv", "file format: csv, plaso-csv, plaso or timesketch")
+	timeColumn := fs.String("time", "datetime", "csv: time column")
+	titleColumn := fs.String("title", "message", "csv: title column")
+	contentColumn := fs.String("content", "", "csv: content column")
+	sourceColumn := fs.String("source", "", "csv: source column")
+	hostColumn := fs.String("host", "", "csv: host column")
+	categoryColumn := fs.String("category-column", "", "csv: event category column")
+	tagsColumn := fs.String("tags-column", "", "csv: tags column")
+	layout := fs.String("layout", "", "csv: Go layout of the time column (default RFC 3339 or \"2006-01-02 15:04:05\")")
+	tz := fs.String("tz", "", "timezone of times without offset and of the created events (default UTC)")
+	from := fs.String("from", "", "skip records before this RFC 3339 time")
+	to := fs.String("to", "", "skip records after this RFC 3339 time")
+	tagged := fs.Bool("tagged", false, "import only tagged records")
+	tags := fs.String("tags", "", "import only records with one of these comma-separated tags")
+	hosts := fs.String("hosts", "", "import only records of these comma-separated hosts")
+	match := fs.String("match", "", "import only records whose title or content matches this regexp")
+	category := fs.String("category", "", "event category of records without category (default Unspecified)")
+	addTags := fs.String("add-tags", "", "comma-separated tags added to every event")
+	linkAssets := fs.Bool("link-assets", false, "link events to the assets named like their host")
+	inSummary := fs.Bool("summary", false, "show the events in the summary")
+	inGraph := fs.Bool("graph", false, "show the events in the graph")
+	dryRun := fs.Bool("dry-run", false, "only show the events that would be created")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireCase(*caseId); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("%w: -f is required", errUsage)
+	}
+
+	options := goiris.TimelineImportOptions{
+		Category:   *category,
+		LinkAssets: *linkAssets,
+		Tags:       splitFlag(*addTags),
+		InSummary:  *inSummary,
+		InGraph:    *inGraph,
+		DryRun:     *dryRun,
+	}
+	options.Filter.Tagged = *tagged
+	options.Filter.Tags = splitFlag(*tags)
+	options.Filter.Hosts = splitFlag(*hosts)
+
+	if *tz != "" {
+		location, err := time.LoadLocation(*tz)
+		if err != nil {
+			return err
+		}
+		options.Location = location
+	}
+	for _, bound := range []struct {
+		value  string
+		target *time.Time
+	}{{*from, &options.Filter.From}, {*to, &options.Filter.To}} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errUsage, err)
+		}
+		*bound.target = t
+	}
+	if *match != "" {
+		re, err := regexp.Compile(*match)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errUsage, err)
+		}
+		options.Filter.Match = re
+	}
+
+	var r io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var records []goiris.TimelineRecord
+	var err error
+	switch *format {
+	case "csv":
+		records, err = goiris.ParseTimelineCSV(r, goiris.TimelineCSVMapping{
+			Time:       *timeColumn,
+			Title:      *titleColumn,
+			Content:    *contentColumn,
+			Source:     *sourceColumn,
+			Host:       *hostColumn,
+			Category:   *categoryColumn,
+			Tags:       *tagsColumn,
+			TimeLayout: *layout,
+			Location:   options.Location,
+		})
+	case "plaso-csv":
+		mapping := goiris.PlasoCSVMapping
+		mapping.Location = options.Location
+		records, err = goiris.ParseTimelineCSV(r, mapping)
+	case "plaso":
+		records, err = goiris.ParsePlasoJSONL(r)
+	case "timesketch":
+		records, err = goiris.ParseTimesketchJSONL(r)
+	default:
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := a.client.ImportTimeline(*caseId, records, options)
+	if result != nil {
+		fmt.Fprintf(os.Stderr, "%d created, %d filtered, %d duplicates, %d linked to assets\n",
+			len(result.Created), result.Filtered, result.Duplicates, result.Linked)
+	}
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, event := range result.Created {
+		rows = append(rows, []string{event.EventDate, event.EventTz, event.EventTitle, event.EventSource, event.EventTags})
+	}
+	return a.render(result.Created, []string{"date", "tz", "title", "source", "tags"}, rows)
+}
+
+// splitFlag splits a comma-separated flag value, dropping empty items
+func splitFlag(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/b401/goiris"
+)
+
+// watchLine is a printed watch event
+type watchLine struct {
+	Kind     goiris.WatchKind   `json:"kind"`
+	Change   goiris.WatchChange `json:"change"`
+	ID       int                `json:"id,omitempty"`
+	PolledAt time.Time          `json:"polled_at"`
+	Object   interface{}        `json:"object,omitempty"`
+}
+
+func runWatch(a *app, args []string) error {
+	fs := newFlagSet("watch")
+	kinds := fs.String("kinds", "case,alert,activity", "comma-separated kinds to watch: case, alert, activity")
+	interval := fs.Duration("interval", time.Minute, "time between polls")
+	state := fs.String("state", "", "directory of the cursor file, resumes from it (default in memory)")
+	existing := fs.Bool("existing", false, "report existing objects on the first poll")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	options := goiris.WatcherOptions{
+		Interval:     *interval,
+		EmitExisting: *existing,
+		OnError: func(err error) {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		},
+	}
+	for _, kind := range splitFlag(*kinds) {
+		switch goiris.WatchKind(kind) {
+		case goiris.WatchCases, goiris.WatchAlerts, goiris.WatchActivities:
+			options.Kinds = append(options.Kinds, goiris.WatchKind(kind))
+		default:
+			return fmt.Errorf("%w: unknown kind %q", errUsage, kind)
+		}
+	}
+	if *state != "" {
+		options.Store = goiris.FileCursorStore{Dir: *state}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	watcher := a.client.NewWatcher(options)
+	done := make(chan error, 1)
+	go func() { done <- watcher.Run(ctx) }()
+
+	// events are printed as JSON lines whatever the output format, a table cannot be streamed
+	encoder := json.NewEncoder(a.stdout)
+	for event := range watcher.Events() {
+		line := watchLine{Kind: event.Kind, Change: event.Change, ID: event.ID, PolledAt: event.PolledAt}
+		switch {
+		case event.Case != nil:
+			line.Object = event.Case
+		case event.Alert != nil:
+			line.Object = event.Alert
+		case event.Activity != nil:
+			line.Object = event.Activity
+		}
+		if err := encoder.Encode(line); err != nil {
+			stop()
+			<-done
+			return err
+		}
+	}
+
+	if err := <-done; err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
@@ -1,3 +1,5 @@
 module github.com/b401/goiris
 
 go 1.22.3
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,339 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ActivitiesResponse represents the response of the /activities/list-all and /case/activities/list endpoints
+type ActivitiesResponse struct {
+	Activities []Activity `json:"data"`
+	ApiMeta
+}
+
+// Activity represents an entry of the IRIS activity log
+type Activity struct {
+	ActivityDate string `json:"activity_date"`
+	ActivityDesc string `json:"activity_desc"`
+	UserInput    bool   `json:"user_input"`
+	IsFromAPI    bool   `json:"is_from_api"`
+	CaseID       int    `json:"case_id"`
+	CaseName     string `json:"case_name"`
+	UserName     string `json:"user_name"`
+}
+
+// activityDateFormats are the layouts IRIS versions use for activity dates, times without zone are UTC
+var activityDateFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999",
+	"2006-01-02 15:04:05.999999",
+	time.RFC1123,
+}
+
+// Time returns the parsed ActivityDate
+func (activity Activity) Time() (time.Time, error) {
+	for _, layout := range activityDateFormats {
+		if t, err := time.Parse(layout, activity.ActivityDate); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid activity date %q", activity.ActivityDate)
+}
+
+// ListActivities gets the activity log of all cases from the /activities/list-all endpoint.
+//
+// Returns:
+// - *ActivitiesResponse*: The response from the API containing the activities in the Activities field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListActivities() (*ActivitiesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/activities/list-all").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var activitiesResponse ActivitiesResponse
+	if err := json.NewDecoder(req.Body).Decode(&activitiesResponse); err != nil {
+		return nil, err
+	}
+
+	return &activitiesResponse, nil
+}
+
+// ListCaseActivities gets the activity log of a case from the /case/activities/list endpoint.
+//
+// Returns:
+// - *ActivitiesResponse*: The response from the API containing the activities in the Activities field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListCaseActivities(caseId int) (*ActivitiesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/case/activities/list").
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var activitiesResponse ActivitiesResponse
+	if err := json.NewDecoder(req.Body).Decode(&activitiesResponse); err != nil {
+		return nil, err
+	}
+
+	// the case endpoint does not repeat the case of its entries
+	for i := range activitiesResponse.Activities {
+		if activitiesResponse.Activities[i].CaseID == 0 {
+			activitiesResponse.Activities[i].CaseID = caseId
+		}
+	}
+
+	return &activitiesResponse, nil
+}
+
+// ActivityFilter selects activities of FilterActivities, zero fields do not filter
+type ActivityFilter struct {
+	// CaseID reads the log of a single case
+	CaseID int
+	// From and To bound the activity dates, From is inclusive and To exclusive
+	From time.Time
+	To   time.Time
+	// User keeps only the activities of this user name
+	User string
+	// FromAPI keeps only activities of API calls if true, or of the UI if false
+	FromAPI *bool
+}
+
+// FilterActivities returns the activities matching the filter sorted by date. IRIS has no server side
+// date filter, the log is fetched and filtered by the client.
+//
+// Example usage:
+//
+//	since := time.Now().Add(-24 * time.Hour)
+//	activities, err := client.FilterActivities(goiris.ActivityFilter{From: since})
+//
+// Returns:
+// - []Activity: The matching activities, oldest first.
+// - error: An error if the request fails or an activity date cannot be parsed.
+func (client *APIClient) FilterActivities(filter ActivityFilter) ([]Activity, error) {
+	var response *ActivitiesResponse
+	var err error
+	if filter.CaseID != 0 {
+		response, err = client.ListCaseActivities(filter.CaseID)
+	} else {
+		response, err = client.ListActivities()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	type dated struct {
+		activity Activity
+		time     time.Time
+	}
+	var matches []dated
+	for _, activity := range response.Activities {
+		t, err := activity.Time()
+		if err != nil {
+			return nil, err
+		}
+		if (!filter.From.IsZero() && t.Before(filter.From)) || (!filter.To.IsZero() && !t.Before(filter.To)) {
+			continue
+		}
+		if filter.User != "" && !strings.EqualFold(activity.UserName, filter.User) {
+			continue
+		}
+		if filter.FromAPI != nil && activity.IsFromAPI != *filter.FromAPI {
+			continue
+		}
+		matches = append(matches, dated{activity, t})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].time.Before(matches[j].time)
+	})
+
+	activities := make([]Activity, 0, len(matches))
+	for _, match := range matches {
+		activities = append(activities, match.activity)
+	}
+	return activities, nil
+}
+
+// ActivityExportFormat is the line format of WriteActivities
+type ActivityExportFormat string
+
+const (
+	// ActivityExportJSONL writes one JSON object per line with an added RFC 3339 "timestamp"
+	ActivityExportJSONL ActivityExportFormat = "jsonl"
+	// ActivityExportSyslog writes one RFC 5424 message per line
+	ActivityExportSyslog ActivityExportFormat = "syslog"
+)
+
+// Syslog facilities and severities of ActivitySyslogOptions
+const (
+	SyslogFacilityKern      = 0
+	SyslogFacilityAuthPriv  = 10
+	SyslogFacilityLogAudit  = 13
+	SyslogFacilityLocal0    = 16
+	SyslogSeverityEmergency = 0
+	SyslogSeverityNotice    = 5
+	SyslogSeverityInfo      = 6
+)
+
+// ActivitySyslogOptions controls the syslog lines of WriteActivities
+type ActivitySyslogOptions struct {
+	// Hostname is the HOSTNAME field, the local hostname if empty
+	Hostname string
+	// AppName is the APP-NAME field, "iris" if empty
+	AppName string
+	// Facility is SyslogFacilityLogAudit if nil, a pointer so facility 0 (kern) can be chosen
+	Facility *int
+	// Severity is SyslogSeverityInfo if nil, a pointer so severity 0 (emergency) can be chosen
+	Severity *int
+	// SDID is the ID of the structured data element, "iris@32473" if empty
+	SDID string
+}
+
+// activityLine is the JSONL line of an activity
+type activityLine struct {
+	Timestamp string `json:"timestamp"`
+	Activity
+}
+
+// WriteActivities writes activities as JSONL or RFC 5424 syslog lines for forwarding to a SIEM. The
+// syslog lines carry case, user and origin of the activity as structured data and the description as
+// message, separated by newlines as in RFC 6587 non-transparent framing.
+//
+// Example usage:
+//
+//	activities, _ := client.FilterActivities(goiris.ActivityFilter{From: since})
+//	err := goiris.WriteActivities(conn, activities, goiris.ActivityExportSyslog, goiris.ActivitySyslogOptions{})
+//
+// Returns:
+// - error: An error if the format is unknown, the facility or severity is out of range, a date cannot be parsed
+// or writing fails.
+func WriteActivities(w io.Writer, activities []Activity, format ActivityExportFormat, options ActivitySyslogOptions) error {
+	switch format {
+	case ActivityExportJSONL:
+		encoder := json.NewEncoder(w)
+		for _, activity := range activities {
+			t, err := activity.Time()
+			if err != nil {
+				return err
+			}
+			if err := encoder.Encode(activityLine{Timestamp: t.Format(time.RFC3339Nano), Activity: activity}); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case ActivityExportSyslog:
+		if options.Hostname == "" {
+			options.Hostname, _ = os.Hostname()
+		}
+		if options.AppName == "" {
+			options.AppName = "iris"
+		}
+		facility, severity := SyslogFacilityLogAudit, SyslogSeverityInfo
+		if options.Facility != nil {
+			facility = *options.Facility
+		}
+		if options.Severity != nil {
+			severity = *options.Severity
+		}
+		if facility < 0 || facility > 23 {
+			return fmt.Errorf("syslog facility %d out of range 0-23", facility)
+		}
+		if severity < 0 || severity > 7 {
+			return fmt.Errorf("syslog severity %d out of range 0-7", severity)
+		}
+		options.Facility, options.Severity = &facility, &severity
+		if options.SDID == "" {
+			options.SDID = "iris@32473"
+		}
+
+		for _, activity := range activities {
+			line, err := syslogLine(activity, options)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, line+"\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown activity export format %q", format)
+}
+
+// syslogLine formats <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [SD] MSG
+func syslogLine(activity Activity, options ActivitySyslogOptions) (string, error) {
+	t, err := activity.Time()
+	if err != nil {
+		return "", err
+	}
+
+	params := []string{
+		syslogParam("case_id", strconv.Itoa(activity.CaseID)),
+		syslogParam("case_name", activity.CaseName),
+		syslogParam("user", activity.UserName),
+		syslogParam("from_api", strconv.FormatBool(activity.IsFromAPI)),
+		syslogParam("user_input", strconv.FormatBool(activity.UserInput)),
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s %s - activity [%s %s] %s",
+		*options.Facility*8+*options.Severity,
+		t.Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogField(options.Hostname, 255),
+		syslogField(options.AppName, 48),
+		options.SDID,
+		strings.Join(params, " "),
+		strings.ReplaceAll(activity.ActivityDesc, "\n", " "),
+	), nil
+}
+
+// syslogParam formats a structured data parameter, escaping '"', '\' and ']'
+func syslogParam(name, value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+	return fmt.Sprintf(`%s="%s"`, name, value)
+}
+
+// syslogField returns a header field of printable ASCII without spaces, "-" if empty
+func syslogField(value string, max int) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return -1
+		}
+		return r
+	}, value)
+	if value == "" {
+		return "-"
+	}
+	if len(value) > max {
+		value = value[:max]
+	}
+	return value
+}
@@ -0,0 +1,101 @@
+package goiris
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteActivitiesSyslog(t *testing.T) {
+	kern, emergency, local0, notice, invalid := SyslogFacilityKern, SyslogSeverityEmergency, SyslogFacilityLocal0, SyslogSeverityNotice, 24
+	activity := Activity{
+		ActivityDate: "2024-03-01T10:00:00.123456",
+		ActivityDesc: "Updated case",
+		UserInput:    true,
+		CaseID:       42,
+		CaseName:     "#42 - Phishing",
+		UserName:     "analyst",
+	}
+
+	tests := []struct {
+		name     string
+		activity Activity
+		options  ActivitySyslogOptions
+		want     string
+		err      string
+	}{
+		{
+			name:     "defaults",
+			activity: activity,
+			options:  ActivitySyslogOptions{Hostname: "collector"},
+			want:     `<110>1 2024-03-01T10:00:00.123456Z collector iris - activity [iris@32473 case_id="42" case_name="#42 - Phishing" user="analyst" from_api="false" user_input="true"] Updated case`,
+		},
+		{
+			name:     "facility and severity 0",
+			activity: activity,
+			options:  ActivitySyslogOptions{Hostname: "collector", Facility: &kern, Severity: &emergency},
+			want:     `<0>1 2024-03-01T10:00:00.123456Z collector iris - activity [iris@32473 case_id="42" case_name="#42 - Phishing" user="analyst" from_api="false" user_input="true"] Updated case`,
+		},
+		{
+			name:     "options",
+			activity: activity,
+			options:  ActivitySyslogOptions{Hostname: "collector", AppName: "dfir-iris", Facility: &local0, Severity: &notice, SDID: "audit@1"},
+			want:     `<133>1 2024-03-01T10:00:00.123456Z collector dfir-iris - activity [audit@1 case_id="42" case_name="#42 - Phishing" user="analyst" from_api="false" user_input="true"] Updated case`,
+		},
+		{
+			name: "structured data escaped",
+			activity: Activity{
+				ActivityDate: "2024-03-01T10:00:00+02:00",
+				ActivityDesc: "line one\nline two",
+				CaseName:     `a "quoted" [name] \ here`,
+				UserName:     "api",
+				IsFromAPI:    true,
+			},
+			options: ActivitySyslogOptions{Hostname: "collector"},
+			want:    `<110>1 2024-03-01T08:00:00.000000Z collector iris - activity [iris@32473 case_id="0" case_name="a \"quoted\" [name\] \\ here" user="api" from_api="true" user_input="false"] line one line two`,
+		},
+		{
+			name:     "header fields printable without spaces",
+			activity: activity,
+			options:  ActivitySyslogOptions{Hostname: "my host\té", AppName: strings.Repeat("a", 60)},
+			want:     `<110>1 2024-03-01T10:00:00.123456Z myhost ` + strings.Repeat("a", 48) + ` - activity [iris@32473 case_id="42" case_name="#42 - Phishing" user="analyst" from_api="false" user_input="true"] Updated case`,
+		},
+		{
+			name:     "header field of only spaces",
+			activity: activity,
+			options:  ActivitySyslogOptions{Hostname: " ", AppName: "iris"},
+			want:     `<110>1 2024-03-01T10:00:00.123456Z - iris - activity [iris@32473 case_id="42" case_name="#42 - Phishing" user="analyst" from_api="false" user_input="true"] Updated case`,
+		},
+		{
+			name:     "facility out of range",
+			activity: activity,
+			options:  ActivitySyslogOptions{Hostname: "collector", Facility: &invalid},
+			err:      "facility 24 out of range",
+		},
+		{
+			name:     "invalid date",
+			activity: Activity{ActivityDate: "yesterday"},
+			options:  ActivitySyslogOptions{Hostname: "collector"},
+			err:      `invalid activity date "yesterday"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteActivities(&buf, []Activity{test.activity}, ActivityExportSyslog, test.options)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("error = %v, want %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != test.want+"\n" {
+				t.Errorf("line =\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
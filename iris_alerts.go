@@ -0,0 +1,189 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// AlertsResponse represents the response of the /alerts/filter endpoint
+type AlertsResponse struct {
+	Data struct {
+		Alerts      []Alert `json:"alerts"`
+		Total       int     `json:"total"`
+		CurrentPage int     `json:"current_page"`
+		LastPage    int     `json:"last_page"`
+		NextPage    *int    `json:"next_page"`
+	} `json:"data"`
+	ApiMeta
+}
+
+// AlertAPIResponse represents the response of a single alert api action
+type AlertAPIResponse struct {
+	Alert Alert `json:"data"`
+	ApiMeta
+}
+
+// Alert represents an alert of the alerting module. Severity, Status, Customer and Classification
+// are only filled by the get and filter endpoints.
+type Alert struct {
+	AlertID               int                    `json:"alert_id"`
+	AlertUUID             string                 `json:"alert_uuid"`
+	AlertTitle            string                 `json:"alert_title"`
+	AlertDescription      string                 `json:"alert_description"`
+	AlertSource           string                 `json:"alert_source"`
+	AlertSourceRef        string                 `json:"alert_source_ref"`
+	AlertSourceLink       string                 `json:"alert_source_link"`
+	AlertSourceEventTime  string                 `json:"alert_source_event_time"`
+	AlertCreationTime     string                 `json:"alert_creation_time"`
+	AlertNote             string                 `json:"alert_note"`
+	AlertTags             string                 `json:"alert_tags"`
+	AlertSeverityID       int                    `json:"alert_severity_id"`
+	AlertStatusID         int                    `json:"alert_status_id"`
+	AlertCustomerID       int                    `json:"alert_customer_id"`
+	AlertClassificationID int                    `json:"alert_classification_id"`
+	AlertOwnerID          int                    `json:"alert_owner_id"`
+	AlertContext          map[string]interface{} `json:"alert_context"`
+	AlertSourceContent    json.RawMessage        `json:"alert_source_content"`
+	Severity              *AlertSeverity         `json:"severity"`
+	Status                *AlertStatus           `json:"status"`
+	Customer              *AlertCustomer         `json:"customer"`
+	Classification        *AlertClassification   `json:"classification"`
+	Cases                 []int                  `json:"cases"`
+	CustomAttributes      CustomAttributes       `json:"custom_attributes"`
+}
+
+// AlertSeverity is the severity embedded in an alert
+type AlertSeverity struct {
+	SeverityID   int    `json:"severity_id"`
+	SeverityName string `json:"severity_name"`
+}
+
+// AlertStatus is the status embedded in an alert
+type AlertStatus struct {
+	StatusID   int    `json:"status_id"`
+	StatusName string `json:"status_name"`
+}
+
+// AlertCustomer is the customer embedded in an alert
+type AlertCustomer struct {
+	CustomerID   int    `json:"customer_id"`
+	CustomerName string `json:"customer_name"`
+}
+
+// AlertClassification is the classification embedded in an alert
+type AlertClassification struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// AlertFilter selects the alerts of FilterAlerts, zero fields do not filter. Dates use "2006-01-02".
+type AlertFilter struct {
+	Page             int
+	PerPage          int
+	Sort             string
+	Title            string
+	Source           string
+	Tags             string
+	StatusID         int
+	SeverityID       int
+	CustomerID       int
+	ClassificationID int
+	OwnerID          int
+	CaseID           int
+	CreationStart    string
+	CreationEnd      string
+	SourceStart      string
+	SourceEnd        string
+}
+
+// FilterAlerts returns one page of the alerts matching the filter from the /alerts/filter endpoint.
+//
+// Example usage:
+//
+//	alerts, err := client.FilterAlerts(goiris.AlertFilter{StatusID: 1, PerPage: 50})
+//
+// Returns:
+// - *AlertsResponse*: The response from the API containing the alerts and the paging in the Data field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) FilterAlerts(filter AlertFilter) (*AlertsResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/alerts/filter").
+		SetMethod(http.MethodGet)
+
+	for key, value := range map[string]int{
+		"page":                    filter.Page,
+		"per_page":                filter.PerPage,
+		"alert_status_id":         filter.StatusID,
+		"alert_severity_id":       filter.SeverityID,
+		"alert_customer_id":       filter.CustomerID,
+		"alert_classification_id": filter.ClassificationID,
+		"alert_owner_id":          filter.OwnerID,
+		"case_id":                 filter.CaseID,
+	} {
+		if value != 0 {
+			builder.AddQueryParam(key, strconv.Itoa(value))
+		}
+	}
+	for key, value := range map[string]string{
+		"sort":                filter.Sort,
+		"alert_title":         filter.Title,
+		"alert_source":        filter.Source,
+		"alert_tags":          filter.Tags,
+		"creation_start_date": filter.CreationStart,
+		"creation_end_date":   filter.CreationEnd,
+		"source_start_date":   filter.SourceStart,
+		"source_end_date":     filter.SourceEnd,
+	} {
+		if value != "" {
+			builder.AddQueryParam(key, value)
+		}
+	}
+
+	req, err := client.DoRequest(*builder.Build())
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var alertsResponse AlertsResponse
+	if err := json.NewDecoder(req.Body).Decode(&alertsResponse); err != nil {
+		return nil, err
+	}
+
+	return &alertsResponse, nil
+}
+
+// GetAlert returns a single alert from the /alerts/<alert-id> endpoint.
+//
+// Returns:
+// - *AlertAPIResponse*: The response from the API containing the alert in the Alert field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetAlert(alertId int) (*AlertAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/alerts/%d", alertId)).
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var alertAPIResponse AlertAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&alertAPIResponse); err != nil {
+		return nil, err
+	}
+
+	return &alertAPIResponse, nil
+}
@@ -0,0 +1,172 @@
+package goiris
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// AssetTypeAPIResponse represents the response of a single asset type api action
+type AssetTypeAPIResponse struct {
+	AssetType AssetType `json:"data"`
+	ApiMeta
+}
+
+// AssetTypeIcon is an icon file uploaded with an asset type, IRIS accepts PNG and SVG files
+type AssetTypeIcon struct {
+	FileName string
+	File     io.Reader
+}
+
+// AssetTypeRequest represents a struct for adding or updating an asset type.
+// Icons left nil are not changed on update.
+type AssetTypeRequest struct {
+	AssetName          string
+	AssetDescription   string
+	IconCompromised    *AssetTypeIcon
+	IconNotCompromised *AssetTypeIcon
+}
+
+// GetAssetType gets a single asset type from the /manage/asset-type/<id> endpoint.
+//
+// Returns:
+// - *AssetTypeAPIResponse*: The response from the API containing the asset type in the AssetType field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetAssetType(assetTypeId int) (*AssetTypeAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/asset-type/%d", assetTypeId)).
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var assetTypeResponse AssetTypeAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&assetTypeResponse); err != nil {
+		return nil, err
+	}
+
+	return &assetTypeResponse, nil
+}
+
+// AddAssetType adds an asset type with its icons through the /manage/asset-type/add endpoint.
+//
+// Example usage:
+//
+//	icon, _ := os.Open("plc.png")
+//	iconCompromised, _ := os.Open("plc_compromised.png")
+//	assetType, err := client.AddAssetType(goiris.AssetTypeRequest{
+//		AssetName:          "OT - PLC",
+//		AssetDescription:   "Programmable logic controller",
+//		IconNotCompromised: &goiris.AssetTypeIcon{FileName: "plc.png", File: icon},
+//		IconCompromised:    &goiris.AssetTypeIcon{FileName: "plc_compromised.png", File: iconCompromised},
+//	})
+//
+// Returns:
+// - *AssetTypeAPIResponse*: The response from the API containing the created asset type in the AssetType field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddAssetType(assetType AssetTypeRequest) (*AssetTypeAPIResponse, error) {
+	return client.submitAssetType("/manage/asset-type/add", assetType)
+}
+
+// UpdateAssetType updates an asset type through the /manage/asset-type/update/<id> endpoint.
+//
+// Returns:
+// - *AssetTypeAPIResponse*: The response from the API containing the updated asset type in the AssetType field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateAssetType(assetTypeId int, assetType AssetTypeRequest) (*AssetTypeAPIResponse, error) {
+	return client.submitAssetType(fmt.Sprintf("/manage/asset-type/update/%d", assetTypeId), assetType)
+}
+
+// DeleteAssetType removes an asset type using the /manage/asset-type/delete/<id> endpoint.
+// IRIS refuses to delete asset types still referenced by assets.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) DeleteAssetType(assetTypeId int) error {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/asset-type/delete/%d", assetTypeId)).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	client.Lookup().Invalidate(LookupAssetType)
+	return nil
+}
+
+func (client *APIClient) submitAssetType(endpoint string, assetType AssetTypeRequest) (*AssetTypeAPIResponse, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	if err := writer.WriteField("asset_name", assetType.AssetName); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteField("asset_description", assetType.AssetDescription); err != nil {
+		return nil, err
+	}
+
+	icons := map[string]*AssetTypeIcon{
+		"asset_icon_compromised":     assetType.IconCompromised,
+		"asset_icon_not_compromised": assetType.IconNotCompromised,
+	}
+	for field, icon := range icons {
+		if icon == nil {
+			continue
+		}
+		part, err := writer.CreateFormFile(field, icon.FileName)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(part, icon.File); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(endpoint).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", writer.FormDataContentType()).
+		SetBody(body.Bytes()).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var assetTypeResponse AssetTypeAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&assetTypeResponse); err != nil {
+		return nil, err
+	}
+
+	client.Lookup().Invalidate(LookupAssetType)
+	return &assetTypeResponse, nil
+}
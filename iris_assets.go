@@ -0,0 +1,277 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Compromise states of an asset
+const (
+	AssetCompromiseToBeDetermined = 0
+	AssetCompromised              = 1
+	AssetNotCompromised           = 2
+	AssetCompromiseUnknown        = 3
+)
+
+// AssetsResponse represents the response of the /case/assets/list endpoint
+type AssetsResponse struct {
+	Data struct {
+		Assets []Asset `json:"assets"`
+	} `json:"data"`
+	ApiMeta
+}
+
+// AssetAPIResponse represents the response of a single asset api action
+type AssetAPIResponse struct {
+	Asset Asset `json:"data"`
+	ApiMeta
+}
+
+// Asset represents an asset of a case. AssetType and AnalysisStatus are only filled by the list endpoint.
+type Asset struct {
+	AssetID                 int              `json:"asset_id"`
+	AssetUUID               string           `json:"asset_uuid"`
+	AssetName               string           `json:"asset_name"`
+	AssetDescription        string           `json:"asset_description"`
+	AssetDomain             string           `json:"asset_domain"`
+	AssetIP                 string           `json:"asset_ip"`
+	AssetInfo               string           `json:"asset_info"`
+	AssetTags               string           `json:"asset_tags"`
+	AssetTypeID             int              `json:"asset_type_id"`
+	AssetType               string           `json:"asset_type"`
+	AnalysisStatusID        int              `json:"analysis_status_id"`
+	AnalysisStatus          string           `json:"analysis_status"`
+	AssetCompromiseStatusID int              `json:"asset_compromise_status_id"`
+	IocLinks                AssetIocLinks    `json:"ioc_links"`
+	CustomAttributes        CustomAttributes `json:"custom_attributes"`
+}
+
+// AssetIocLinks are the IDs of the IOCs linked to an asset. IRIS returns them as IOC objects on the list
+// endpoint, as IDs elsewhere or as null; all forms are accepted. They are written as IDs.
+type AssetIocLinks []int
+
+// UnmarshalJSON reads null, a list of IDs or a list of IOC objects with an ioc_id field
+func (links *AssetIocLinks) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("ioc_links: %w", err)
+	}
+	if raw == nil {
+		*links = nil
+		return nil
+	}
+
+	ids := make(AssetIocLinks, 0, len(raw))
+	for _, entry := range raw {
+		var id int
+		if err := json.Unmarshal(entry, &id); err == nil {
+			ids = append(ids, id)
+			continue
+		}
+		var ioc struct {
+			IocID int `json:"ioc_id"`
+		}
+		if err := json.Unmarshal(entry, &ioc); err != nil {
+			return fmt.Errorf("ioc_links: %w", err)
+		}
+		ids = append(ids, ioc.IocID)
+	}
+	*links = ids
+	return nil
+}
+
+// AssetRequest represents a struct for adding or updating an asset of a case
+type AssetRequest struct {
+	AssetName               string          `json:"asset_name"`
+	AssetTypeID             int             `json:"asset_type_id"`
+	AnalysisStatusID        int             `json:"analysis_status_id"`
+	AssetDescription        string          `json:"asset_description,omitempty"`
+	AssetDomain             string          `json:"asset_domain,omitempty"`
+	AssetIP                 string          `json:"asset_ip,omitempty"`
+	AssetInfo               string          `json:"asset_info,omitempty"`
+	AssetTags               string          `json:"asset_tags,omitempty"`
+	AssetCompromiseStatusID int             `json:"asset_compromise_status_id,omitempty"`
+	IocLinks                []int           `json:"ioc_links,omitempty"`
+	CustomAttributes        AttributeValues `json:"custom_attributes,omitempty"`
+}
+
+// ListAssets gets all assets of a case from the /case/assets/list endpoint.
+//
+// Example usage:
+//
+//	assets, err := client.ListAssets(42)
+//	if err != nil {
+//	    log.Fatalf("Failed to list assets: %v", err)
+//	}
+//	for _, asset := range assets.Data.Assets {
+//		fmt.Println(asset.AssetName, asset.AssetType)
+//	}
+//
+// Returns:
+// - *AssetsResponse*: The response from the API containing the assets in the Data.Assets field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListAssets(caseId int) (*AssetsResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/case/assets/list").
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var assetsResponse AssetsResponse
+	if err := json.NewDecoder(req.Body).Decode(&assetsResponse); err != nil {
+		return nil, err
+	}
+
+	return &assetsResponse, nil
+}
+
+// GetAsset returns a single asset of a case from the /case/assets/<asset-id> endpoint.
+//
+// Returns:
+// - *AssetAPIResponse*: The response from the API containing the asset in the Asset field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetAsset(caseId int, assetId int) (*AssetAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/assets/%d", assetId)).
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var assetResponse AssetAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&assetResponse); err != nil {
+		return nil, err
+	}
+
+	return &assetResponse, nil
+}
+
+// AddAsset adds an asset to a case through the /case/assets/add endpoint.
+//
+// Example usage:
+//
+//	assetTypeId, _ := client.Lookup().AssetTypeID("Windows - Computer")
+//	asset, err := client.AddAsset(42, goiris.AssetRequest{
+//		AssetName:        "WKS-0042",
+//		AssetTypeID:      assetTypeId,
+//		AnalysisStatusID: 1,
+//		AssetIP:          "10.0.0.42",
+//	})
+//
+// Returns:
+// - *AssetAPIResponse*: The response from the API containing the created asset in the Asset field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddAsset(caseId int, asset AssetRequest) (*AssetAPIResponse, error) {
+	jsondata, err := json.Marshal(asset)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/case/assets/add").
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var assetResponse AssetAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&assetResponse); err != nil {
+		return nil, err
+	}
+
+	return &assetResponse, nil
+}
+
+// UpdateAsset updates an asset of a case through the /case/assets/update/<asset-id> endpoint.
+//
+// Returns:
+// - *AssetAPIResponse*: The response from the API containing the updated asset in the Asset field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateAsset(caseId int, assetId int, asset AssetRequest) (*AssetAPIResponse, error) {
+	jsondata, err := json.Marshal(asset)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/assets/update/%d", assetId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var assetResponse AssetAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&assetResponse); err != nil {
+		return nil, err
+	}
+
+	return &assetResponse, nil
+}
+
+// DeleteAsset removes an asset from a case using the /case/assets/delete/<asset-id> endpoint.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) DeleteAsset(caseId int, assetId int) error {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/assets/delete/%d", assetId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return nil
+}
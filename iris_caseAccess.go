@@ -0,0 +1,293 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CaseAccessLevel is the access of a user or group to a case. IRIS stores it as a bitmask.
+type CaseAccessLevel int
+
+const (
+	CaseAccessNone       CaseAccessLevel = 0 // no access was granted, IRIS denies access
+	CaseAccessDenyAll    CaseAccessLevel = 1
+	CaseAccessReadOnly   CaseAccessLevel = 2
+	CaseAccessFullAccess CaseAccessLevel = 4
+)
+
+var caseAccessLevelNames = map[CaseAccessLevel]string{
+	CaseAccessNone:       "none",
+	CaseAccessDenyAll:    "deny_all",
+	CaseAccessReadOnly:   "read_only",
+	CaseAccessFullAccess: "full_access",
+}
+
+// String returns the IRIS name of the level, e.g. read_only
+func (level CaseAccessLevel) String() string {
+	if name, ok := caseAccessLevelNames[level]; ok {
+		return name
+	}
+	return strconv.Itoa(int(level))
+}
+
+// ParseCaseAccessLevel parses the IRIS name of an access level, e.g. full_access. Dashes and case are ignored.
+func ParseCaseAccessLevel(name string) (CaseAccessLevel, error) {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+	for level, levelName := range caseAccessLevelNames {
+		if levelName == normalized {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: case access level %q", ErrLookupNotFound, name)
+}
+
+// CanRead reports whether the level allows to see the case
+func (level CaseAccessLevel) CanRead() bool {
+	return level&CaseAccessDenyAll == 0 && level&(CaseAccessReadOnly|CaseAccessFullAccess) != 0
+}
+
+// CanWrite reports whether the level allows to change the case
+func (level CaseAccessLevel) CanWrite() bool {
+	return level&CaseAccessDenyAll == 0 && level&CaseAccessFullAccess != 0
+}
+
+// CaseAccess is the access granted on a case to a user or group
+type CaseAccess struct {
+	CaseID      int             `json:"case_id"`
+	CaseName    string          `json:"case_name"`
+	AccessLevel CaseAccessLevel `json:"access_level"`
+}
+
+// CaseUsersResponse represents the response of the /case/users/list endpoint
+type CaseUsersResponse struct {
+	Users []CaseUser `json:"data"`
+	ApiMeta
+}
+
+// CaseUser is a user with access to a case and its effective access level
+type CaseUser struct {
+	UserID      int             `json:"user_id"`
+	UserLogin   string          `json:"user_login"`
+	UserName    string          `json:"user_name"`
+	AccessLevel CaseAccessLevel `json:"user_access_level"`
+}
+
+// caseAccessRequest represents the body of the cases-access/update endpoints
+type caseAccessRequest struct {
+	CasesList   []int           `json:"cases_list"`
+	AccessLevel CaseAccessLevel `json:"access_level"`
+}
+
+// caseAccessDeleteRequest represents the body of the cases-access/delete endpoints
+type caseAccessDeleteRequest struct {
+	Cases []int `json:"cases"`
+}
+
+// SetUserCaseAccess grants a user the given access to cases through the
+// /manage/users/<user-id>/cases-access/update endpoint. Access set on the user takes precedence over
+// the access of their groups.
+//
+// Example usage:
+//
+//	contractor, _ := client.Lookup().UserID("contractor")
+//	_, err := client.SetUserCaseAccess(contractor, goiris.CaseAccessReadOnly, 42)
+//
+// Returns:
+// - *UserAPIResponse*: The response from the API containing the updated user in the User field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) SetUserCaseAccess(userId int, level CaseAccessLevel, caseIds ...int) (*UserAPIResponse, error) {
+	var userResponse UserAPIResponse
+	url := fmt.Sprintf("/manage/users/%d/cases-access/update", userId)
+	if err := client.postCaseAccess(url, caseAccessRequest{CasesList: caseIds, AccessLevel: level}, &userResponse); err != nil {
+		return nil, err
+	}
+	return &userResponse, nil
+}
+
+// RemoveUserCaseAccess removes the access set on a user for cases using the
+// /manage/users/<user-id>/cases-access/delete endpoint. The user keeps the access of their groups.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) RemoveUserCaseAccess(userId int, caseIds ...int) error {
+	url := fmt.Sprintf("/manage/users/%d/cases-access/delete", userId)
+	return client.postCaseAccess(url, caseAccessDeleteRequest{Cases: caseIds}, nil)
+}
+
+// SetGroupCaseAccess grants the members of a group the given access to cases through the
+// /manage/groups/<group-id>/cases-access/update endpoint.
+//
+// Returns:
+// - *GroupAPIResponse*: The response from the API containing the updated group in the Group field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) SetGroupCaseAccess(groupId int, level CaseAccessLevel, caseIds ...int) (*GroupAPIResponse, error) {
+	var groupResponse GroupAPIResponse
+	url := fmt.Sprintf("/manage/groups/%d/cases-access/update", groupId)
+	if err := client.postCaseAccess(url, caseAccessRequest{CasesList: caseIds, AccessLevel: level}, &groupResponse); err != nil {
+		return nil, err
+	}
+	return &groupResponse, nil
+}
+
+// RemoveGroupCaseAccess removes the access of a group to cases using the
+// /manage/groups/<group-id>/cases-access/delete endpoint.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) RemoveGroupCaseAccess(groupId int, caseIds ...int) error {
+	url := fmt.Sprintf("/manage/groups/%d/cases-access/delete", groupId)
+	return client.postCaseAccess(url, caseAccessDeleteRequest{Cases: caseIds}, nil)
+}
+
+// ListCaseUsers gets the users with access to a case from the /case/users/list endpoint.
+//
+// Returns:
+// - *CaseUsersResponse*: The response from the API containing the users in the Users field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListCaseUsers(caseId int) (*CaseUsersResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/case/users/list").
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var caseUsersResponse CaseUsersResponse
+	if err := json.NewDecoder(req.Body).Decode(&caseUsersResponse); err != nil {
+		return nil, err
+	}
+
+	return &caseUsersResponse, nil
+}
+
+// CaseAccessGrants is everything that grants a user access to a case
+type CaseAccessGrants struct {
+	CaseID int `json:"case_id"`
+	UserID int `json:"user_id"`
+	// User is the access set on the user itself, nil if there is none
+	User *CaseAccessLevel `json:"user,omitempty"`
+	// Groups is the access of each group of the user to the case, by group name
+	Groups map[string]CaseAccessLevel `json:"groups,omitempty"`
+}
+
+// Effective computes the access level IRIS applies: access set on the user wins, otherwise the access of
+// all groups is combined and a group denying access overrides the others. The administrator permission to
+// access all cases is not taken into account.
+func (grants CaseAccessGrants) Effective() CaseAccessLevel {
+	level := CaseAccessNone
+	if grants.User != nil {
+		level = *grants.User
+	} else {
+		for _, groupLevel := range grants.Groups {
+			level |= groupLevel
+		}
+	}
+
+	switch {
+	case level&CaseAccessDenyAll != 0:
+		return CaseAccessDenyAll
+	case level&CaseAccessFullAccess != 0:
+		return CaseAccessFullAccess
+	case level&CaseAccessReadOnly != 0:
+		return CaseAccessReadOnly
+	}
+	return CaseAccessNone
+}
+
+// GetCaseAccessGrants collects the access set on a user and on each of their groups for a case. It needs
+// one request for the user and one per group.
+//
+// Returns:
+// - *CaseAccessGrants*: The grants, see Effective for the resulting level.
+// - error: An error if a request fails.
+func (client *APIClient) GetCaseAccessGrants(userId, caseId int) (*CaseAccessGrants, error) {
+	user, err := client.GetUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := &CaseAccessGrants{CaseID: caseId, UserID: userId, Groups: map[string]CaseAccessLevel{}}
+	for _, access := range user.User.UserCasesAccess {
+		if access.CaseID == caseId {
+			level := access.AccessLevel
+			grants.User = &level
+		}
+	}
+
+	for _, userGroup := range user.User.UserGroups {
+		group, err := client.GetGroup(userGroup.GroupID)
+		if err != nil {
+			return nil, fmt.Errorf("group %s: %w", userGroup.GroupName, err)
+		}
+		for _, access := range group.Group.GroupCasesAccess {
+			if access.CaseID == caseId {
+				grants.Groups[userGroup.GroupName] |= access.AccessLevel
+			}
+		}
+	}
+
+	return grants, nil
+}
+
+// EffectiveCaseAccess computes the access level of a user on a case from the access set on the user and
+// their groups, see CaseAccessGrants.Effective.
+//
+// Example usage:
+//
+//	level, err := client.EffectiveCaseAccess(contractor, 42)
+//	if err == nil && !level.CanWrite() {
+//	    fmt.Println("read only:", level)
+//	}
+//
+// Returns:
+// - CaseAccessLevel: The effective level, CaseAccessNone if nothing grants access.
+// - error: An error if a request fails.
+func (client *APIClient) EffectiveCaseAccess(userId, caseId int) (CaseAccessLevel, error) {
+	grants, err := client.GetCaseAccessGrants(userId, caseId)
+	if err != nil {
+		return CaseAccessNone, err
+	}
+	return grants.Effective(), nil
+}
+
+// postCaseAccess posts body to a cases-access endpoint and decodes the answer into response unless it is nil
+func (client *APIClient) postCaseAccess(url string, body interface{}, response interface{}) error {
+	jsondata, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(url).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	if response == nil {
+		return nil
+	}
+	return json.NewDecoder(req.Body).Decode(response)
+}
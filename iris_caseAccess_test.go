@@ -0,0 +1,78 @@
+package goiris
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCaseAccessGrantsEffective(t *testing.T) {
+	level := func(level CaseAccessLevel) *CaseAccessLevel { return &level }
+
+	tests := []struct {
+		name   string
+		grants CaseAccessGrants
+		want   CaseAccessLevel
+	}{
+		{"nothing granted", CaseAccessGrants{}, CaseAccessNone},
+		{"user only", CaseAccessGrants{User: level(CaseAccessReadOnly)}, CaseAccessReadOnly},
+		{"user wins over groups", CaseAccessGrants{User: level(CaseAccessReadOnly), Groups: map[string]CaseAccessLevel{"analysts": CaseAccessFullAccess}}, CaseAccessReadOnly},
+		{"user deny wins over groups", CaseAccessGrants{User: level(CaseAccessDenyAll), Groups: map[string]CaseAccessLevel{"analysts": CaseAccessFullAccess}}, CaseAccessDenyAll},
+		{"user none is kept", CaseAccessGrants{User: level(CaseAccessNone), Groups: map[string]CaseAccessLevel{"analysts": CaseAccessFullAccess}}, CaseAccessNone},
+		{"single group", CaseAccessGrants{Groups: map[string]CaseAccessLevel{"analysts": CaseAccessReadOnly}}, CaseAccessReadOnly},
+		{"groups combined", CaseAccessGrants{Groups: map[string]CaseAccessLevel{"readers": CaseAccessReadOnly, "responders": CaseAccessFullAccess}}, CaseAccessFullAccess},
+		{"group deny wins", CaseAccessGrants{Groups: map[string]CaseAccessLevel{"responders": CaseAccessFullAccess, "contractors": CaseAccessDenyAll}}, CaseAccessDenyAll},
+		{"groups without access", CaseAccessGrants{Groups: map[string]CaseAccessLevel{"a": CaseAccessNone, "b": CaseAccessNone}}, CaseAccessNone},
+		{"combined bits of a single grant", CaseAccessGrants{User: level(CaseAccessReadOnly | CaseAccessFullAccess)}, CaseAccessFullAccess},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.grants.Effective(); got != test.want {
+				t.Errorf("Effective() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseCaseAccessLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		want CaseAccessLevel
+		err  error
+	}{
+		{"read_only", CaseAccessReadOnly, nil},
+		{" Full-Access ", CaseAccessFullAccess, nil},
+		{"DENY_ALL", CaseAccessDenyAll, nil},
+		{"none", CaseAccessNone, nil},
+		{"admin", 0, ErrLookupNotFound},
+	}
+
+	for _, test := range tests {
+		got, err := ParseCaseAccessLevel(test.name)
+		if !errors.Is(err, test.err) || got != test.want {
+			t.Errorf("ParseCaseAccessLevel(%q) = %s, %v, want %s, %v", test.name, got, err, test.want, test.err)
+		}
+		if err == nil && got.String() != caseAccessLevelNames[test.want] {
+			t.Errorf("%s.String() = %q", got, got.String())
+		}
+	}
+}
+
+func TestCaseAccessLevelPermissions(t *testing.T) {
+	tests := []struct {
+		level             CaseAccessLevel
+		canRead, canWrite bool
+	}{
+		{CaseAccessNone, false, false},
+		{CaseAccessDenyAll, false, false},
+		{CaseAccessReadOnly, true, false},
+		{CaseAccessFullAccess, true, true},
+		{CaseAccessDenyAll | CaseAccessFullAccess, false, false},
+	}
+
+	for _, test := range tests {
+		if test.level.CanRead() != test.canRead || test.level.CanWrite() != test.canWrite {
+			t.Errorf("%s: CanRead %v, CanWrite %v, want %v, %v", test.level, test.level.CanRead(), test.level.CanWrite(), test.canRead, test.canWrite)
+		}
+	}
+}
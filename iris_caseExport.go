@@ -0,0 +1,604 @@
+package goiris
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// CaseArchiveFormat selects the container written by ExportCase
+type CaseArchiveFormat string
+
+const (
+	CaseArchiveZip   CaseArchiveFormat = "zip"
+	CaseArchiveTarGz CaseArchiveFormat = "tar.gz"
+)
+
+// CaseArchiveFormatVersion is the layout version written to the manifest
+const CaseArchiveFormatVersion = 1
+
+// Entries of a case archive
+const (
+	CaseArchiveManifest        = "manifest.json"
+	CaseArchiveCase            = "case.json"
+	CaseArchiveSummary         = "summary.md"
+	CaseArchiveReferences      = "references.json"
+	CaseArchiveAssets          = "assets.json"
+	CaseArchiveIocs            = "iocs.json"
+	CaseArchiveTimeline        = "timeline.json"
+	CaseArchiveTasks           = "tasks.json"
+	CaseArchiveNoteDirectories = "note_directories.json"
+	CaseArchiveNotes           = "notes.json"
+	CaseArchiveEvidences       = "evidences.json"
+	CaseArchiveComments        = "comments.json"
+	CaseArchiveDatastoreTree   = "datastore/tree.json"
+	CaseArchiveDatastoreFiles  = "datastore/files/"
+)
+
+// CaseManifest describes a case archive. It is written last, so it can list the hash of every other entry.
+type CaseManifest struct {
+	FormatVersion int                 `json:"format_version"`
+	ExportedAt    time.Time           `json:"exported_at"`
+	Source        string              `json:"source"`
+	CaseID        int                 `json:"case_id"`
+	CaseName      string              `json:"case_name"`
+	Entries       []CaseArchiveEntry  `json:"entries"`
+	Datastore     []CaseDatastoreFile `json:"datastore"`
+}
+
+// CaseArchiveEntry is a single file of the archive with its SHA-256
+type CaseArchiveEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// CaseDatastoreFile maps an archived datastore file to its original location in the datastore
+type CaseDatastoreFile struct {
+	FileID        int    `json:"file_id"`
+	DatastorePath string `json:"datastore_path"`
+	ArchivePath   string `json:"archive_path"`
+}
+
+// CaseReferences holds the reference data of the source instance the IDs in the archive refer to
+type CaseReferences struct {
+	Customer            Customer             `json:"customer"`
+	AssetTypes          []AssetType          `json:"asset_types"`
+	IocTypes            []IocType            `json:"ioc_types"`
+	CaseClassifications []CaseClassification `json:"case_classifications"`
+	CaseStates          []CaseState          `json:"case_states"`
+	Severities          []Severity           `json:"severities"`
+	EvidenceTypes       []EvidenceType       `json:"evidence_types"`
+	EventCategories     []EventCategory      `json:"event_categories"`
+	AnalysisStatuses    []AnalysisStatus     `json:"analysis_statuses"`
+	Tlps                []Tlp                `json:"tlps"`
+	TaskStatuses        []TaskStatus         `json:"task_statuses"`
+	Users               []User               `json:"users"`
+}
+
+// CaseComments holds the comments of every commented object, keyed by kind and object ID
+type CaseComments map[CommentKind]map[int][]Comment
+
+// ExportCase writes the complete case caseId into a single archive: case details, summary, assets,
+// IOCs, timeline, tasks, notes, evidences, comments, the datastore and a manifest.json with the
+// SHA-256 of every entry. Everything is streamed into w, datastore files are never held in memory. Tar
+// entries need their size up front, so tar.gz archives spool each datastore file to a temporary file first.
+//
+// Example usage:
+//
+//	file, err := os.Create("case-42.zip")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer file.Close()
+//
+//	manifest, err := client.ExportCase(42, file, goiris.CaseArchiveZip)
+//	if err != nil {
+//	    log.Fatalf("Failed to export case: %v", err)
+//	}
+//	fmt.Println(len(manifest.Entries), "entries written")
+//
+// Returns:
+// - *CaseManifest*: The manifest written into the archive.
+// - error: An error if a request fails or the archive cannot be written. The archive is incomplete in that case.
+func (client *APIClient) ExportCase(caseId int, w io.Writer, format CaseArchiveFormat) (*CaseManifest, error) {
+	archive, err := newCaseArchiveWriter(w, format)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := client.exportCase(caseId, archive)
+	if err != nil {
+		archive.Close()
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// CaseSnapshot holds everything of a case. The content of datastore files is only read when ImportCase
+// uploads them, from the source instance or the archive the snapshot came from.
+// It is what ExportCase writes and what ImportCase recreates.
+type CaseSnapshot struct {
+	Case            Case
+	References      CaseReferences
+	Assets          []Asset
+	Iocs            []Ioc
+	TimelineEvents  []TimelineEvent
+	Tasks           []CaseTask
+	NoteDirectories []NoteDirectory
+	Notes           []Note
+	Evidences       []Evidence
+	Comments        CaseComments
+	Datastore       DatastoreTreeResponse
+	// datastore passes the content of each of files to visit, nil if the content is not available
+	datastore func(files []DatastoreFile, visit func(file DatastoreFile, r io.Reader) error) error
+}
+
+// SnapshotCase fetches the complete case caseId, including the full content of every note and the comments of
+// every object. Datastore files are only listed, their content is downloaded when the snapshot is imported.
+//
+// Returns:
+// - *CaseSnapshot*: The case and all of its objects.
+// - error: An error if one of the requests fails.
+func (client *APIClient) SnapshotCase(caseId int) (*CaseSnapshot, error) {
+	caseResponse, err := client.GetCase(caseId)
+	if err != nil {
+		return nil, fmt.Errorf("case: %w", err)
+	}
+	snapshot := &CaseSnapshot{Case: caseResponse.Case, Comments: make(CaseComments)}
+
+	references, err := client.caseReferences(snapshot.Case)
+	if err != nil {
+		return nil, fmt.Errorf("references: %w", err)
+	}
+	snapshot.References = *references
+
+	addComments := func(kind CommentKind, objectId int) error {
+		commentsResponse, err := client.ListComments(kind, caseId, objectId)
+		if err != nil {
+			return fmt.Errorf("comments of %s %d: %w", kind, objectId, err)
+		}
+		if len(commentsResponse.Comments) == 0 {
+			return nil
+		}
+		if snapshot.Comments[kind] == nil {
+			snapshot.Comments[kind] = make(map[int][]Comment)
+		}
+		snapshot.Comments[kind][objectId] = commentsResponse.Comments
+		return nil
+	}
+
+	assets, err := client.ListAssets(caseId)
+	if err != nil {
+		return nil, fmt.Errorf("assets: %w", err)
+	}
+	snapshot.Assets = assets.Data.Assets
+	for _, asset := range snapshot.Assets {
+		if err := addComments(CommentOnAsset, asset.AssetID); err != nil {
+			return nil, err
+		}
+	}
+
+	iocs, err := client.ListIocs(caseId)
+	if err != nil {
+		return nil, fmt.Errorf("iocs: %w", err)
+	}
+	snapshot.Iocs = iocs.Data.Iocs
+	for _, ioc := range snapshot.Iocs {
+		if err := addComments(CommentOnIoc, ioc.IocID); err != nil {
+			return nil, err
+		}
+	}
+
+	events, err := client.ListTimelineEvents(caseId)
+	if err != nil {
+		return nil, fmt.Errorf("timeline: %w", err)
+	}
+	snapshot.TimelineEvents = events.Data.Events
+	for _, event := range snapshot.TimelineEvents {
+		if err := addComments(CommentOnEvent, event.EventID); err != nil {
+			return nil, err
+		}
+	}
+
+	tasks, err := client.ListCaseTasks(caseId)
+	if err != nil {
+		return nil, fmt.Errorf("tasks: %w", err)
+	}
+	snapshot.Tasks = tasks.Data.Tasks
+	for _, task := range snapshot.Tasks {
+		if err := addComments(CommentOnTask, task.TaskID); err != nil {
+			return nil, err
+		}
+	}
+
+	directories, err := client.ListNoteDirectories(caseId)
+	if err != nil {
+		return nil, fmt.Errorf("notes: %w", err)
+	}
+	snapshot.NoteDirectories = directories.NoteDirectories
+	var walk func(directories []NoteDirectory) error
+	walk = func(directories []NoteDirectory) error {
+		for _, directory := range directories {
+			for _, listed := range directory.Notes {
+				noteResponse, err := client.GetNote(caseId, listed.NoteID)
+				if err != nil {
+					return fmt.Errorf("note %d: %w", listed.NoteID, err)
+				}
+				note := noteResponse.Note
+				if note.DirectoryID == 0 {
+					note.DirectoryID = directory.ID
+				}
+				snapshot.Notes = append(snapshot.Notes, note)
+				if err := addComments(CommentOnNote, listed.NoteID); err != nil {
+					return err
+				}
+			}
+			if err := walk(directory.Subdirectories); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(snapshot.NoteDirectories); err != nil {
+		return nil, err
+	}
+
+	evidences, err := client.ListEvidences(caseId)
+	if err != nil {
+		return nil, fmt.Errorf("evidences: %w", err)
+	}
+	snapshot.Evidences = evidences.Data.Evidences
+	for _, evidence := range snapshot.Evidences {
+		if err := addComments(CommentOnEvidence, evidence.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	tree, err := client.GetDatastoreTree(caseId)
+	if err != nil {
+		return nil, fmt.Errorf("datastore: %w", err)
+	}
+	snapshot.Datastore = *tree
+	snapshot.datastore = func(files []DatastoreFile, visit func(file DatastoreFile, r io.Reader) error) error {
+		for _, file := range files {
+			r, w := io.Pipe()
+			go func() {
+				_, err := client.DownloadDatastoreFile(caseId, file.ID, w)
+				w.CloseWithError(err)
+			}()
+			err := visit(file, r)
+			r.Close()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return snapshot, nil
+}
+
+func (client *APIClient) exportCase(caseId int, archive *caseArchiveWriter) (*CaseManifest, error) {
+	snapshot, err := client.SnapshotCase(caseId)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &CaseManifest{
+		FormatVersion: CaseArchiveFormatVersion,
+		ExportedAt:    time.Now().UTC(),
+		Source:        client.BaseURL,
+		CaseID:        caseId,
+		CaseName:      snapshot.Case.CaseName,
+	}
+
+	if err := archive.writeBytes(CaseArchiveSummary, []byte(snapshot.Case.CaseDescription)); err != nil {
+		return nil, err
+	}
+	entries := []struct {
+		path  string
+		value interface{}
+	}{
+		{CaseArchiveCase, snapshot.Case},
+		{CaseArchiveReferences, snapshot.References},
+		{CaseArchiveAssets, snapshot.Assets},
+		{CaseArchiveIocs, snapshot.Iocs},
+		{CaseArchiveTimeline, snapshot.TimelineEvents},
+		{CaseArchiveTasks, snapshot.Tasks},
+		{CaseArchiveNoteDirectories, snapshot.NoteDirectories},
+		{CaseArchiveNotes, snapshot.Notes},
+		{CaseArchiveEvidences, snapshot.Evidences},
+		{CaseArchiveComments, snapshot.Comments},
+		{CaseArchiveDatastoreTree, snapshot.Datastore.Tree},
+	}
+	for _, entry := range entries {
+		if err := archive.writeJSON(entry.path, entry.value); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range snapshot.Datastore.Files() {
+		archivePath := fmt.Sprintf("%s%d/%s", CaseArchiveDatastoreFiles, file.ID, file.Path)
+		err := archive.writeStream(archivePath, func(w io.Writer) error {
+			_, err := client.DownloadDatastoreFile(caseId, file.ID, w)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("datastore file %s: %w", file.Path, err)
+		}
+		manifest.Datastore = append(manifest.Datastore, CaseDatastoreFile{
+			FileID:        file.ID,
+			DatastorePath: file.Path,
+			ArchivePath:   archivePath,
+		})
+	}
+
+	if err := archive.finishEntry(); err != nil {
+		return nil, err
+	}
+	manifest.Entries = archive.entries
+	if err := archive.writeJSON(CaseArchiveManifest, manifest); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+func (client *APIClient) caseReferences(c Case) (*CaseReferences, error) {
+	references := &CaseReferences{}
+
+	if c.ClientID != 0 {
+		customer, err := client.GetCustomer(c.ClientID)
+		if err != nil {
+			return nil, err
+		}
+		references.Customer = customer.Customer
+	}
+
+	assetTypes, err := client.ListAssetTypes()
+	if err != nil {
+		return nil, err
+	}
+	references.AssetTypes = assetTypes.AssetTypes
+
+	iocTypes, err := client.ListIocTypes()
+	if err != nil {
+		return nil, err
+	}
+	references.IocTypes = iocTypes.IocTypes
+
+	classifications, err := client.ListCaseClassifications()
+	if err != nil {
+		return nil, err
+	}
+	references.CaseClassifications = classifications.CaseClassifications
+
+	evidenceTypes, err := client.ListEvidenceTypes()
+	if err != nil {
+		return nil, err
+	}
+	references.EvidenceTypes = evidenceTypes.EvidenceTypes
+
+	categories, err := client.ListEventCategories()
+	if err != nil {
+		return nil, err
+	}
+	references.EventCategories = categories.EventCategories
+
+	statuses, err := client.ListAnalysisStatuses()
+	if err != nil {
+		return nil, err
+	}
+	references.AnalysisStatuses = statuses.AnalysisStatuses
+
+	tlps, err := client.ListTlps()
+	if err != nil {
+		return nil, err
+	}
+	references.Tlps = tlps.Tlps
+
+	states, err := client.ListCaseStates()
+	if err != nil {
+		return nil, err
+	}
+	references.CaseStates = states.CaseStates
+
+	severities, err := client.ListSeverities()
+	if err != nil {
+		return nil, err
+	}
+	references.Severities = severities.Severities
+
+	taskStatuses, err := client.ListTaskStatuses()
+	if err != nil {
+		return nil, err
+	}
+	references.TaskStatuses = taskStatuses.TaskStatuses
+
+	users, err := client.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+	references.Users = users.Users
+
+	return references, nil
+}
+
+// caseArchiveWriter writes entries into a zip or tar.gz archive and records their size and hash
+type caseArchiveWriter struct {
+	zip *zip.Writer
+	gz  *gzip.Writer
+	tar *tar.Writer
+
+	entries []CaseArchiveEntry
+	current *hashingWriter
+}
+
+type hashingWriter struct {
+	w    io.Writer
+	path string
+	// declared is the size given to create, written must match it for tar archives
+	declared int64
+	size     int64
+	sum      interface {
+		io.Writer
+		Sum([]byte) []byte
+	}
+}
+
+func (hw *hashingWriter) Write(p []byte) (int, error) {
+	n, err := hw.w.Write(p)
+	hw.size += int64(n)
+	hw.sum.Write(p[:n])
+	return n, err
+}
+
+func newCaseArchiveWriter(w io.Writer, format CaseArchiveFormat) (*caseArchiveWriter, error) {
+	switch format {
+	case CaseArchiveZip:
+		return &caseArchiveWriter{zip: zip.NewWriter(w)}, nil
+	case CaseArchiveTarGz:
+		gz := gzip.NewWriter(w)
+		return &caseArchiveWriter{gz: gz, tar: tar.NewWriter(gz)}, nil
+	}
+	return nil, fmt.Errorf("unsupported case archive format: %s", format)
+}
+
+// create starts a new entry, size is required for tar archives and must match the bytes written
+func (archive *caseArchiveWriter) create(path string, size int64) (io.Writer, error) {
+	if err := archive.finishEntry(); err != nil {
+		return nil, err
+	}
+
+	var w io.Writer
+	if archive.zip != nil {
+		entry, err := archive.zip.CreateHeader(&zip.FileHeader{
+			Name:     path,
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		w = entry
+	} else {
+		err := archive.tar.WriteHeader(&tar.Header{
+			Name:    path,
+			Mode:    0o644,
+			Size:    size,
+			ModTime: time.Now(),
+			Format:  tar.FormatPAX,
+		})
+		if err != nil {
+			return nil, err
+		}
+		w = archive.tar
+	}
+
+	archive.current = &hashingWriter{w: w, path: path, declared: size, sum: sha256.New()}
+	return archive.current, nil
+}
+
+// finishEntry records the size and hash of the entry written last
+func (archive *caseArchiveWriter) finishEntry() error {
+	if archive.current == nil {
+		return nil
+	}
+	current := archive.current
+	archive.current = nil
+
+	if archive.tar != nil {
+		// a size mismatch would shift all following tar entries
+		if current.size != current.declared {
+			return fmt.Errorf("%s: %d bytes written, the tar entry was created for %d", current.path, current.size, current.declared)
+		}
+		if err := archive.tar.Flush(); err != nil {
+			return fmt.Errorf("%s: %w", current.path, err)
+		}
+	}
+
+	archive.entries = append(archive.entries, CaseArchiveEntry{
+		Path:   current.path,
+		Size:   current.size,
+		SHA256: hex.EncodeToString(current.sum.Sum(nil)),
+	})
+	return nil
+}
+
+func (archive *caseArchiveWriter) writeBytes(path string, data []byte) error {
+	w, err := archive.create(path, int64(len(data)))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// writeStream adds an entry with everything write writes. Tar entries need their size up front, so for tar
+// archives the content is spooled to a temporary file first instead of trusting a listed size.
+func (archive *caseArchiveWriter) writeStream(path string, write func(w io.Writer) error) error {
+	if archive.tar == nil {
+		w, err := archive.create(path, 0)
+		if err != nil {
+			return err
+		}
+		return write(w)
+	}
+
+	spool, err := os.CreateTemp("", "goiris-case-archive-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	if err := write(spool); err != nil {
+		return err
+	}
+	size, err := spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	w, err := archive.create(path, size)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, spool)
+	return err
+}
+
+func (archive *caseArchiveWriter) writeJSON(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	return archive.writeBytes(path, data)
+}
+
+func (archive *caseArchiveWriter) Close() error {
+	if err := archive.finishEntry(); err != nil {
+		return err
+	}
+	if archive.zip != nil {
+		return archive.zip.Close()
+	}
+	if err := archive.tar.Close(); err != nil {
+		return err
+	}
+	return archive.gz.Close()
+}
@@ -0,0 +1,990 @@
+package goiris
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrCaseArchiveHash is returned when an entry of a case archive does not match the hash of the manifest
+var ErrCaseArchiveHash = errors.New("case archive: hash mismatch")
+
+// ErrCaseImportMismatch is returned when a progress file belongs to another source case
+var ErrCaseImportMismatch = errors.New("case import: progress file belongs to another case")
+
+// Objects recorded in the ID maps of a CaseImportProgress
+const (
+	CaseImportIocs            = "iocs"
+	CaseImportAssets          = "assets"
+	CaseImportEvents          = "events"
+	CaseImportTasks           = "tasks"
+	CaseImportNoteDirectories = "note_directories"
+	CaseImportNotes           = "notes"
+	CaseImportEvidences       = "evidences"
+	CaseImportComments        = "comments"
+	CaseImportDatastoreFiles  = "datastore_files"
+)
+
+// commentImportObjects are the ID maps of the objects of each comment kind
+var commentImportObjects = map[CommentKind]string{
+	CommentOnAsset:    CaseImportAssets,
+	CommentOnIoc:      CaseImportIocs,
+	CommentOnEvent:    CaseImportEvents,
+	CommentOnTask:     CaseImportTasks,
+	CommentOnNote:     CaseImportNotes,
+	CommentOnEvidence: CaseImportEvidences,
+}
+
+// casePrefix matches the "#<id> - " IRIS puts in front of every case name
+var casePrefix = regexp.MustCompile(`^#\d+ - `)
+
+// CaseImportOptions controls ImportCase
+type CaseImportOptions struct {
+	// CustomerName is the customer of the imported case on the target, by default the source customer is used
+	CustomerName string
+	// ProgressFile records the ID of every created object. An import started again with the same
+	// file continues where it stopped instead of creating duplicates.
+	ProgressFile string
+}
+
+// CaseImportProgress maps the IDs of the source case to the objects created on the target
+type CaseImportProgress struct {
+	SourceCaseID   int                    `json:"source_case_id"`
+	SourceCaseUUID string                 `json:"source_case_uuid"`
+	TargetCaseID   int                    `json:"target_case_id"`
+	IDs            map[string]map[int]int `json:"ids"`
+	CaseUpdated    bool                   `json:"case_updated"`
+	CaseClosed     bool                   `json:"case_closed"`
+	Completed      bool                   `json:"completed"`
+	path           string
+}
+
+// CaseImportResult is the outcome of ImportCase
+type CaseImportResult struct {
+	CaseID   int
+	Progress *CaseImportProgress
+	// Warnings lists what could not be carried over, such as users unknown on the target
+	Warnings []string
+}
+
+// ReadCaseArchive reads an archive written by ExportCase, zip and tar.gz are detected automatically.
+// Every entry is checked against the hashes of the manifest. Datastore files stay in the archive until
+// ImportCase uploads them in a single pass over the archive, their hash is checked then.
+//
+// Example usage:
+//
+//	snapshot, manifest, err := goiris.ReadCaseArchive("case-42.zip")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := target.ImportCase(snapshot, goiris.CaseImportOptions{ProgressFile: "case-42.progress.json"})
+//
+// Returns:
+// - *CaseSnapshot*: The case stored in the archive.
+// - *CaseManifest*: The manifest of the archive.
+// - error: An error if the archive cannot be read, is incomplete or an entry does not match its hash.
+func ReadCaseArchive(file string) (*CaseSnapshot, *CaseManifest, error) {
+	entries := make(map[string][]byte)
+	err := walkCaseArchive(file, func(name string, r io.Reader) (bool, error) {
+		// datastore files are read when they are imported
+		if strings.HasPrefix(name, CaseArchiveDatastoreFiles) {
+			return false, nil
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", name, err)
+		}
+		entries[name] = data
+		return false, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, ok := entries[CaseArchiveManifest]
+	if !ok {
+		return nil, nil, fmt.Errorf("%s: %s missing", file, CaseArchiveManifest)
+	}
+	var manifest CaseManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", CaseArchiveManifest, err)
+	}
+	if manifest.FormatVersion > CaseArchiveFormatVersion {
+		return nil, nil, fmt.Errorf("%s: unsupported format version %d", file, manifest.FormatVersion)
+	}
+	for _, entry := range manifest.Entries {
+		if strings.HasPrefix(entry.Path, CaseArchiveDatastoreFiles) {
+			continue
+		}
+		data, ok := entries[entry.Path]
+		if !ok {
+			return nil, nil, fmt.Errorf("%s: %s missing", file, entry.Path)
+		}
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != entry.SHA256 {
+			return nil, nil, fmt.Errorf("%w: %s", ErrCaseArchiveHash, entry.Path)
+		}
+	}
+
+	snapshot := &CaseSnapshot{}
+	values := map[string]interface{}{
+		CaseArchiveCase:            &snapshot.Case,
+		CaseArchiveReferences:      &snapshot.References,
+		CaseArchiveAssets:          &snapshot.Assets,
+		CaseArchiveIocs:            &snapshot.Iocs,
+		CaseArchiveTimeline:        &snapshot.TimelineEvents,
+		CaseArchiveTasks:           &snapshot.Tasks,
+		CaseArchiveNoteDirectories: &snapshot.NoteDirectories,
+		CaseArchiveNotes:           &snapshot.Notes,
+		CaseArchiveEvidences:       &snapshot.Evidences,
+		CaseArchiveComments:        &snapshot.Comments,
+		CaseArchiveDatastoreTree:   &snapshot.Datastore.Tree,
+	}
+	for name, value := range values {
+		if err := json.Unmarshal(entries[name], value); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	hashes := make(map[string]string, len(manifest.Entries))
+	for _, entry := range manifest.Entries {
+		hashes[entry.Path] = entry.SHA256
+	}
+	archivePaths := make(map[int]string, len(manifest.Datastore))
+	for _, datastoreFile := range manifest.Datastore {
+		if _, ok := hashes[datastoreFile.ArchivePath]; ok {
+			archivePaths[datastoreFile.FileID] = datastoreFile.ArchivePath
+		}
+	}
+	snapshot.datastore = func(files []DatastoreFile, visit func(file DatastoreFile, r io.Reader) error) error {
+		pending := make(map[string]DatastoreFile, len(files))
+		for _, datastoreFile := range files {
+			archivePath, ok := archivePaths[datastoreFile.ID]
+			if !ok {
+				return fmt.Errorf("%s: not in the archive", datastoreFile.Path)
+			}
+			pending[archivePath] = datastoreFile
+		}
+
+		// a single pass, the files are visited in the order of the archive
+		err := walkCaseArchive(file, func(name string, r io.Reader) (bool, error) {
+			datastoreFile, ok := pending[name]
+			if !ok {
+				return false, nil
+			}
+			delete(pending, name)
+			if err := visit(datastoreFile, &hashingReader{r: r, hash: sha256.New(), name: name, sum: hashes[name]}); err != nil {
+				return true, err
+			}
+			return len(pending) == 0, nil
+		})
+		if err == nil && len(pending) > 0 {
+			missing := make(map[string]bool, len(pending))
+			for name := range pending {
+				missing[name] = true
+			}
+			err = fmt.Errorf("%s: %s missing", file, strings.Join(sortedKeys(missing), ", "))
+		}
+		return err
+	}
+
+	return snapshot, &manifest, nil
+}
+
+// walkCaseArchive passes every regular file of a zip or tar.gz archive to visit until visit returns true
+func walkCaseArchive(file string, visit func(name string, r io.Reader) (bool, error)) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	magic, err := bufio.NewReader(f).Peek(4)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	switch {
+	case bytes.Equal(magic, []byte("PK\x03\x04")):
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		archive, err := zip.NewReader(f, info.Size())
+		if err != nil {
+			return err
+		}
+		for _, entry := range archive.File {
+			if strings.HasSuffix(entry.Name, "/") {
+				continue
+			}
+			r, err := entry.Open()
+			if err != nil {
+				return err
+			}
+			done, err := visit(filepath.ToSlash(entry.Name), r)
+			r.Close()
+			if done || err != nil {
+				return err
+			}
+		}
+	case bytes.Equal(magic[:2], []byte{0x1f, 0x8b}):
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		archive := tar.NewReader(gz)
+		for {
+			header, err := archive.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if header.Typeflag != tar.TypeReg {
+				continue
+			}
+			done, err := visit(filepath.ToSlash(header.Name), archive)
+			if done || err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%s: not a zip or tar.gz case archive", file)
+	}
+	return nil
+}
+
+// hashingReader fails the read of the end of r if its content does not match the SHA-256 sum
+type hashingReader struct {
+	r    io.Reader
+	hash hash.Hash
+	name string
+	sum  string
+}
+
+func (reader *hashingReader) Read(p []byte) (int, error) {
+	n, err := reader.r.Read(p)
+	reader.hash.Write(p[:n])
+	if err == io.EOF && hex.EncodeToString(reader.hash.Sum(nil)) != reader.sum {
+		return n, fmt.Errorf("%w: %s", ErrCaseArchiveHash, reader.name)
+	}
+	return n, err
+}
+
+// MigrateCase copies the case caseId of this client to target, see ImportCase.
+//
+// Example usage:
+//
+//	result, err := oldInstance.MigrateCase(newInstance, 42, goiris.CaseImportOptions{
+//		ProgressFile: "migration-42.json",
+//	})
+//
+// Returns:
+// - *CaseImportResult*: The ID of the new case, the ID mapping and warnings.
+// - error: An error if the case cannot be read or imported.
+func (client *APIClient) MigrateCase(target *APIClient, caseId int, options CaseImportOptions) (*CaseImportResult, error) {
+	snapshot, err := client.SnapshotCase(caseId)
+	if err != nil {
+		return nil, err
+	}
+	return target.ImportCase(snapshot, options)
+}
+
+// ImportCase recreates a case on this client's instance, typically read with ReadCaseArchive or SnapshotCase
+// from another instance. Customer, users, asset types, IOC types, TLPs, classifications, states, severities,
+// evidence types, event categories, analysis statuses and task statuses are mapped to the target by name.
+// Links between assets and IOCs, events and their assets and IOCs, parent events and notes and their
+// directories point to the new objects.
+//
+// All names are resolved before anything is created, missing reference data is reported at once.
+// Users unknown on the target are left unassigned and reported in Warnings. Comments are added by the API user
+// with the original author and date in front of the text. Datastore files are streamed from the archive or
+// the source instance into the same folders, missing folders are created.
+//
+// With options.ProgressFile, the ID of every created object is saved right away and an interrupted import
+// resumes from the file.
+//
+// Returns:
+// - *CaseImportResult*: The ID of the new case, the ID mapping and warnings.
+// - error: An error if a name cannot be mapped or a request fails. The progress is kept in that case.
+func (client *APIClient) ImportCase(snapshot *CaseSnapshot, options CaseImportOptions) (*CaseImportResult, error) {
+	progress, err := loadCaseImportProgress(options.ProgressFile, snapshot.Case)
+	if err != nil {
+		return nil, err
+	}
+	result := &CaseImportResult{CaseID: progress.TargetCaseID, Progress: progress}
+	if progress.Completed {
+		return result, nil
+	}
+
+	mapper := newCaseImportMapper(client, snapshot)
+	if err := mapper.resolveAll(); err != nil {
+		return nil, err
+	}
+
+	customerName := options.CustomerName
+	if customerName == "" {
+		customerName = snapshot.References.Customer.CustomerName
+	}
+	if customerName == "" {
+		customerName = snapshot.Case.ClientName
+	}
+	customer, err := client.FindCustomerByName(customerName)
+	if err != nil {
+		return nil, err
+	}
+
+	source := snapshot.Case
+	if progress.TargetCaseID == 0 {
+		created, err := client.AddCase(AddCaseRequest{
+			CaseCustomer:     customer.CustomerID,
+			CaseName:         casePrefix.ReplaceAllString(source.CaseName, ""),
+			CaseDescription:  source.CaseDescription,
+			CaseSocID:        source.CaseSocID,
+			ClassificationID: mapper.id(LookupCaseClassification, source.ClassificationID, source.Classification),
+			CustomAttributes: source.CustomAttributes.Values(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("case: %w", err)
+		}
+		progress.TargetCaseID = created.Case.CaseID
+		result.CaseID = progress.TargetCaseID
+		if err := progress.save(); err != nil {
+			return nil, err
+		}
+	}
+	caseId := progress.TargetCaseID
+
+	for _, ioc := range snapshot.Iocs {
+		err := progress.create(CaseImportIocs, ioc.IocID, func() (int, error) {
+			created, err := client.AddIoc(caseId, IocRequest{
+				IocValue:         ioc.IocValue,
+				IocTypeID:        mapper.id(LookupIocType, ioc.IocTypeID, ioc.IocType),
+				IocTlpID:         mapper.id(LookupTlp, ioc.IocTlpID, ioc.TlpName),
+				IocDescription:   ioc.IocDescription,
+				IocTags:          ioc.IocTags,
+				CustomAttributes: ioc.CustomAttributes.Values(),
+			})
+			if err != nil {
+				return 0, err
+			}
+			return created.Ioc.IocID, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("ioc %q: %w", ioc.IocValue, err)
+		}
+	}
+
+	for _, asset := range snapshot.Assets {
+		err := progress.create(CaseImportAssets, asset.AssetID, func() (int, error) {
+			created, err := client.AddAsset(caseId, AssetRequest{
+				AssetName:               asset.AssetName,
+				AssetTypeID:             mapper.id(LookupAssetType, asset.AssetTypeID, asset.AssetType),
+				AnalysisStatusID:        mapper.id(LookupAnalysisStatus, asset.AnalysisStatusID, asset.AnalysisStatus),
+				AssetDescription:        asset.AssetDescription,
+				AssetDomain:             asset.AssetDomain,
+				AssetIP:                 asset.AssetIP,
+				AssetInfo:               asset.AssetInfo,
+				AssetTags:               asset.AssetTags,
+				AssetCompromiseStatusID: asset.AssetCompromiseStatusID,
+				IocLinks:                progress.mapIDs(CaseImportIocs, asset.IocLinks),
+				CustomAttributes:        asset.CustomAttributes.Values(),
+			})
+			if err != nil {
+				return 0, err
+			}
+			return created.Asset.AssetID, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("asset %q: %w", asset.AssetName, err)
+		}
+	}
+
+	// parents are created before their children, events with an unknown parent are created without it
+	pending := append([]TimelineEvent(nil), snapshot.TimelineEvents...)
+	known := make(map[int]bool)
+	for _, event := range pending {
+		known[event.EventID] = true
+	}
+	for len(pending) > 0 {
+		var deferred []TimelineEvent
+		for _, event := range pending {
+			_, parentDone := progress.IDs[CaseImportEvents][event.ParentEventID]
+			if event.ParentEventID != 0 && known[event.ParentEventID] && !parentDone {
+				deferred = append(deferred, event)
+				continue
+			}
+			if err := client.importTimelineEvent(caseId, event, mapper, progress); err != nil {
+				return nil, fmt.Errorf("event %q: %w", event.EventTitle, err)
+			}
+		}
+		if len(deferred) == len(pending) {
+			// a parent cycle, break it
+			for _, event := range deferred {
+				known[event.EventID] = false
+			}
+		}
+		pending = deferred
+	}
+
+	for _, task := range snapshot.Tasks {
+		err := progress.create(CaseImportTasks, task.TaskID, func() (int, error) {
+			var assignees []int
+			for _, assignee := range task.TaskAssignees {
+				if id := mapper.user(assignee.ID, assignee.User); id != 0 {
+					assignees = append(assignees, id)
+				}
+			}
+			created, err := client.AddCaseTask(caseId, CaseTaskRequest{
+				TaskTitle:        task.TaskTitle,
+				TaskStatusID:     mapper.id(LookupTaskStatus, task.TaskStatusID, task.StatusName),
+				TaskDescription:  task.TaskDescription,
+				TaskTags:         task.TaskTags,
+				TaskAssigneesID:  assignees,
+				CustomAttributes: task.CustomAttributes.Values(),
+			})
+			if err != nil {
+				return 0, err
+			}
+			return created.Task.TaskID, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("task %q: %w", task.TaskTitle, err)
+		}
+	}
+
+	var importDirectories func(directories []NoteDirectory, parentId int) error
+	importDirectories = func(directories []NoteDirectory, parentId int) error {
+		for _, directory := range directories {
+			err := progress.create(CaseImportNoteDirectories, directory.ID, func() (int, error) {
+				created, err := client.AddNoteDirectory(caseId, NoteDirectoryRequest{
+					Name:     directory.Name,
+					ParentID: parentId,
+				})
+				if err != nil {
+					return 0, err
+				}
+				return created.NoteDirectory.ID, nil
+			})
+			if err != nil {
+				return fmt.Errorf("note directory %q: %w", directory.Name, err)
+			}
+			if err := importDirectories(directory.Subdirectories, progress.IDs[CaseImportNoteDirectories][directory.ID]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := importDirectories(snapshot.NoteDirectories, 0); err != nil {
+		return nil, err
+	}
+
+	for _, note := range snapshot.Notes {
+		directoryId, ok := progress.IDs[CaseImportNoteDirectories][note.DirectoryID]
+		if !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("note %q: directory %d not found, skipped", note.NoteTitle, note.DirectoryID))
+			continue
+		}
+		err := progress.create(CaseImportNotes, note.NoteID, func() (int, error) {
+			created, err := client.AddNote(caseId, NoteRequest{
+				NoteTitle:        note.NoteTitle,
+				NoteContent:      note.NoteContent,
+				DirectoryID:      directoryId,
+				CustomAttributes: note.CustomAttributes.Values(),
+			})
+			if err != nil {
+				return 0, err
+			}
+			return created.Note.NoteID, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("note %q: %w", note.NoteTitle, err)
+		}
+	}
+
+	for _, evidence := range snapshot.Evidences {
+		err := progress.create(CaseImportEvidences, evidence.ID, func() (int, error) {
+			created, err := client.AddEvidence(caseId, EvidenceRequest{
+				Filename:         evidence.Filename,
+				FileSize:         evidence.FileSize,
+				FileHash:         evidence.FileHash,
+				FileDescription:  evidence.FileDescription,
+				TypeID:           mapper.id(LookupEvidenceType, evidence.TypeID, ""),
+				CustomAttributes: evidence.CustomAttributes.Values(),
+			})
+			if err != nil {
+				return 0, err
+			}
+			return created.Evidence.ID, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("evidence %q: %w", evidence.Filename, err)
+		}
+	}
+
+	for _, kind := range CommentKinds {
+		objects := snapshot.Comments[kind]
+		sourceIds := make([]int, 0, len(objects))
+		for sourceId := range objects {
+			sourceIds = append(sourceIds, sourceId)
+		}
+		sort.Ints(sourceIds)
+
+		for _, sourceId := range sourceIds {
+			objectId, ok := progress.IDs[commentImportObjects[kind]][sourceId]
+			if !ok {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%d comments of %s %d: object not imported, skipped", len(objects[sourceId]), kind, sourceId))
+				continue
+			}
+			for _, comment := range objects[sourceId] {
+				err := progress.create(CaseImportComments, comment.CommentID, func() (int, error) {
+					text := fmt.Sprintf("_%s, %s:_\n\n%s", firstNonEmpty(comment.Name, comment.User, "unknown"), comment.CommentDate, comment.CommentText)
+					created, err := client.AddComment(kind, caseId, objectId, text)
+					if err != nil {
+						return 0, err
+					}
+					return created.Comment.CommentID, nil
+				})
+				if err != nil {
+					return nil, fmt.Errorf("comment %d of %s %d: %w", comment.CommentID, kind, sourceId, err)
+				}
+			}
+		}
+	}
+
+	if err := client.importDatastoreFiles(caseId, snapshot, progress); err != nil {
+		return nil, err
+	}
+
+	// the case itself is updated and closed last, once everything else was added
+	if !progress.CaseUpdated {
+		_, err = client.UpdateCase(caseId, UpdateCaseRequest{
+			StateID:    mapper.id(LookupCaseState, source.StateID, source.StateName),
+			SeverityID: mapper.id(LookupSeverity, source.SeverityID, ""),
+			OwnerID:    mapper.user(source.OwnerID, ""),
+			CaseTags:   source.CaseTags,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("case: %w", err)
+		}
+		progress.CaseUpdated = true
+		if err := progress.save(); err != nil {
+			return nil, err
+		}
+	}
+	if source.CaseCloseDate != "" && !progress.CaseClosed {
+		if _, err := client.CloseCase(caseId); err != nil {
+			return nil, fmt.Errorf("case: %w", err)
+		}
+		progress.CaseClosed = true
+		if err := progress.save(); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Warnings = append(result.Warnings, mapper.warnings...)
+	if files := snapshot.Datastore.Files(); len(files) > 0 && snapshot.datastore == nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%d datastore files not imported, their content is not part of the snapshot", len(files)))
+	}
+
+	progress.Completed = true
+	if err := progress.save(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// importDatastoreFiles uploads the datastore files of snapshot into folders with the same path on the target
+func (client *APIClient) importDatastoreFiles(caseId int, snapshot *CaseSnapshot, progress *CaseImportProgress) error {
+	files := snapshot.Datastore.Files()
+	if len(files) == 0 || snapshot.datastore == nil {
+		return nil
+	}
+
+	var pending []DatastoreFile
+	for _, file := range files {
+		if _, ok := progress.IDs[CaseImportDatastoreFiles][file.ID]; !ok {
+			pending = append(pending, file)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	var folders map[string]int
+	folder := func(dir string) (int, error) {
+		if folders == nil {
+			tree, err := client.GetDatastoreTree(caseId)
+			if err != nil {
+				return 0, err
+			}
+			folders = tree.Folders()
+		}
+		if dir == "." {
+			dir = ""
+		}
+		if id, ok := folders[dir]; ok {
+			return id, nil
+		}
+		// created from the root down, the parents of existing folders exist as well
+		parentPath := ""
+		for _, name := range strings.Split(dir, "/") {
+			folderPath := path.Join(parentPath, name)
+			if _, ok := folders[folderPath]; !ok {
+				created, err := client.AddDatastoreFolder(caseId, folders[parentPath], name)
+				if err != nil {
+					return 0, fmt.Errorf("datastore folder %q: %w", folderPath, err)
+				}
+				folders[folderPath] = created.Folder.PathID
+			}
+			parentPath = folderPath
+		}
+		return folders[dir], nil
+	}
+
+	// each file is streamed from the source into the upload
+	return snapshot.datastore(pending, func(file DatastoreFile, r io.Reader) error {
+		err := progress.create(CaseImportDatastoreFiles, file.ID, func() (int, error) {
+			folderId, err := folder(path.Dir(file.Path))
+			if err != nil {
+				return 0, err
+			}
+			created, err := client.AddDatastoreFile(caseId, folderId, DatastoreFileRequest{
+				FileOriginalName: path.Base(file.Path),
+				FileDescription:  file.FileDescription,
+				FilePassword:     file.FilePassword,
+				FileTags:         file.FileTags,
+				FileIsEvidence:   file.FileIsEvidence,
+				FileIsIoc:        file.FileIsIoc,
+				File:             r,
+			})
+			if err != nil {
+				return 0, err
+			}
+			return created.File.FileID, nil
+		})
+		if err != nil {
+			return fmt.Errorf("datastore file %q: %w", file.Path, err)
+		}
+		return nil
+	})
+}
+
+func (client *APIClient) importTimelineEvent(caseId int, event TimelineEvent, mapper *caseImportMapper, progress *CaseImportProgress) error {
+	return progress.create(CaseImportEvents, event.EventID, func() (int, error) {
+		var assets, iocs []int
+		for _, asset := range event.Assets {
+			assets = append(assets, asset.AssetID)
+		}
+		for _, ioc := range event.Iocs {
+			iocs = append(iocs, ioc.IocID)
+		}
+		created, err := client.AddTimelineEvent(caseId, TimelineEventRequest{
+			EventTitle:       event.EventTitle,
+			EventDate:        event.EventDate,
+			EventTz:          event.EventTz,
+			EventCategoryID:  mapper.id(LookupEventCategory, event.EventCategoryID, event.CategoryName),
+			EventAssets:      progress.mapIDs(CaseImportAssets, assets),
+			EventIocs:        progress.mapIDs(CaseImportIocs, iocs),
+			EventContent:     event.EventContent,
+			EventRaw:         event.EventRaw,
+			EventSource:      event.EventSource,
+			EventTags:        event.EventTags,
+			EventColor:       event.EventColor,
+			EventInSummary:   event.EventInSummary,
+			EventInGraph:     event.EventInGraph,
+			ParentEventID:    progress.IDs[CaseImportEvents][event.ParentEventID],
+			CustomAttributes: event.CustomAttributes.Values(),
+		})
+		if err != nil {
+			return 0, err
+		}
+		return created.Event.EventID, nil
+	})
+}
+
+func loadCaseImportProgress(file string, source Case) (*CaseImportProgress, error) {
+	progress := &CaseImportProgress{
+		SourceCaseID:   source.CaseID,
+		SourceCaseUUID: source.CaseUUID,
+		IDs:            make(map[string]map[int]int),
+		path:           file,
+	}
+	if file == "" {
+		return progress, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return progress, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, progress); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if progress.SourceCaseID != source.CaseID || progress.SourceCaseUUID != source.CaseUUID {
+		return nil, fmt.Errorf("%w: %s is for case %d", ErrCaseImportMismatch, file, progress.SourceCaseID)
+	}
+	if progress.IDs == nil {
+		progress.IDs = make(map[string]map[int]int)
+	}
+	return progress, nil
+}
+
+// save writes the progress file, through a temporary file so an interruption never leaves it truncated
+func (progress *CaseImportProgress) save() error {
+	if progress.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := progress.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, progress.path)
+}
+
+// create calls add unless the source object was already imported and records the new ID
+func (progress *CaseImportProgress) create(objects string, sourceId int, add func() (int, error)) error {
+	if _, ok := progress.IDs[objects][sourceId]; ok {
+		return nil
+	}
+
+	id, err := add()
+	if err != nil {
+		return err
+	}
+	if progress.IDs[objects] == nil {
+		progress.IDs[objects] = make(map[int]int)
+	}
+	progress.IDs[objects][sourceId] = id
+	return progress.save()
+}
+
+// mapIDs returns the new IDs of the given source objects, objects that were not imported are dropped
+func (progress *CaseImportProgress) mapIDs(objects string, sourceIds []int) []int {
+	ids := []int{}
+	for _, sourceId := range sourceIds {
+		if id, ok := progress.IDs[objects][sourceId]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// caseImportMapper maps reference data IDs of the source instance to the target by name
+type caseImportMapper struct {
+	snapshot *CaseSnapshot
+	lookup   *Lookup
+	names    map[LookupKind]map[int]string
+	ids      map[LookupKind]map[caseImportKey]int
+	warnings []string
+}
+
+// caseImportKey identifies a resolved reference. Objects without a source ID are only known by name,
+// so the name is part of the key.
+type caseImportKey struct {
+	sourceId int
+	name     string
+}
+
+func newCaseImportMapper(target *APIClient, snapshot *CaseSnapshot) *caseImportMapper {
+	references := snapshot.References
+	names := make(map[LookupKind]map[int]string)
+	add := func(kind LookupKind, id int, name string) {
+		if names[kind] == nil {
+			names[kind] = make(map[int]string)
+		}
+		names[kind][id] = name
+	}
+	for _, entry := range references.AssetTypes {
+		add(LookupAssetType, entry.AssetID, entry.AssetName)
+	}
+	for _, entry := range references.IocTypes {
+		add(LookupIocType, entry.TypeID, entry.TypeName)
+	}
+	for _, entry := range references.CaseClassifications {
+		add(LookupCaseClassification, entry.ID, entry.Name)
+	}
+	for _, entry := range references.CaseStates {
+		add(LookupCaseState, entry.StateID, entry.StateName)
+	}
+	for _, entry := range references.Severities {
+		add(LookupSeverity, entry.SeverityID, entry.SeverityName)
+	}
+	for _, entry := range references.EvidenceTypes {
+		add(LookupEvidenceType, entry.ID, entry.Name)
+	}
+	for _, entry := range references.EventCategories {
+		add(LookupEventCategory, entry.ID, entry.Name)
+	}
+	for _, entry := range references.AnalysisStatuses {
+		add(LookupAnalysisStatus, entry.ID, entry.Name)
+	}
+	for _, entry := range references.Tlps {
+		add(LookupTlp, entry.TlpID, entry.TlpName)
+	}
+	for _, entry := range references.TaskStatuses {
+		add(LookupTaskStatus, entry.ID, entry.StatusName)
+	}
+	for _, entry := range references.Users {
+		add(LookupUser, entry.UserID, entry.UserLogin)
+	}
+
+	return &caseImportMapper{
+		snapshot: snapshot,
+		lookup:   target.Lookup(),
+		names:    names,
+		ids:      make(map[LookupKind]map[caseImportKey]int),
+	}
+}
+
+// resolve maps the source ID to the target, name is used when the references do not know the ID
+func (mapper *caseImportMapper) resolve(kind LookupKind, sourceId int, name string) (int, error) {
+	key := caseImportKey{sourceId: sourceId, name: name}
+	if id, ok := mapper.ids[kind][key]; ok {
+		return id, nil
+	}
+	if sourceId == 0 && name == "" {
+		return 0, nil
+	}
+
+	if known, ok := mapper.names[kind][sourceId]; ok {
+		name = known
+	}
+	if name == "" {
+		return 0, fmt.Errorf("%w: %s %d has no name in the source references", ErrLookupNotFound, kind, sourceId)
+	}
+	id, err := mapper.lookup.ID(kind, name)
+	if err != nil {
+		return 0, err
+	}
+
+	if mapper.ids[kind] == nil {
+		mapper.ids[kind] = make(map[caseImportKey]int)
+	}
+	mapper.ids[kind][key] = id
+	return id, nil
+}
+
+// id returns the resolved target ID, resolveAll must have succeeded before
+func (mapper *caseImportMapper) id(kind LookupKind, sourceId int, name string) int {
+	id, _ := mapper.resolve(kind, sourceId, name)
+	return id
+}
+
+// user returns the target ID of a source user or 0 with a warning if the target does not know the login
+func (mapper *caseImportMapper) user(sourceId int, login string) int {
+	if sourceId == 0 && login == "" {
+		return 0
+	}
+	id, err := mapper.resolve(LookupUser, sourceId, login)
+	if err != nil {
+		if mapper.ids[LookupUser] == nil {
+			mapper.ids[LookupUser] = make(map[caseImportKey]int)
+		}
+		key := caseImportKey{sourceId: sourceId, name: login}
+		if _, warned := mapper.ids[LookupUser][key]; !warned {
+			mapper.ids[LookupUser][key] = 0
+			mapper.warnings = append(mapper.warnings, fmt.Sprintf("user %d %q left unassigned: %v", sourceId, login, err))
+		}
+	}
+	return id
+}
+
+// caseImportReference is a reference data ID of the source, with the name the object itself carries if any
+type caseImportReference struct {
+	kind     LookupKind
+	sourceId int
+	name     string
+}
+
+// resolveAll resolves every reference of the snapshot and reports all that are missing on the target
+func (mapper *caseImportMapper) resolveAll() error {
+	missing := make(map[string]bool)
+	check := func(kind LookupKind, sourceId int, name string) error {
+		_, err := mapper.resolve(kind, sourceId, name)
+		if errors.Is(err, ErrLookupNotFound) {
+			missing[err.Error()] = true
+			return nil
+		}
+		return err
+	}
+
+	source := mapper.snapshot
+	checks := []caseImportReference{
+		{LookupCaseClassification, source.Case.ClassificationID, source.Case.Classification},
+		{LookupCaseState, source.Case.StateID, source.Case.StateName},
+		{LookupSeverity, source.Case.SeverityID, ""},
+	}
+	for _, ioc := range source.Iocs {
+		checks = append(checks,
+			caseImportReference{LookupIocType, ioc.IocTypeID, ioc.IocType},
+			caseImportReference{LookupTlp, ioc.IocTlpID, ioc.TlpName})
+	}
+	for _, asset := range source.Assets {
+		checks = append(checks,
+			caseImportReference{LookupAssetType, asset.AssetTypeID, asset.AssetType},
+			caseImportReference{LookupAnalysisStatus, asset.AnalysisStatusID, asset.AnalysisStatus})
+	}
+	for _, event := range source.TimelineEvents {
+		checks = append(checks, caseImportReference{LookupEventCategory, event.EventCategoryID, event.CategoryName})
+	}
+	for _, task := range source.Tasks {
+		checks = append(checks, caseImportReference{LookupTaskStatus, task.TaskStatusID, task.StatusName})
+	}
+	for _, evidence := range source.Evidences {
+		checks = append(checks, caseImportReference{LookupEvidenceType, evidence.TypeID, ""})
+	}
+
+	for _, c := range checks {
+		if err := check(c.kind, c.sourceId, c.name); err != nil {
+			return err
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+	var errs []error
+	for _, message := range sortedKeys(missing) {
+		errs = append(errs, errors.New(message))
+	}
+	return fmt.Errorf("%w: the target instance is missing reference data:\n%w", ErrLookupNotFound, errors.Join(errs...))
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,269 @@
+package goiris
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrSummaryConflict is returned when the case summary changed since it was read
+var ErrSummaryConflict = errors.New("case summary: changed since it was read")
+
+// CaseSummaryAPIResponse represents the response of the /case/summary endpoints
+type CaseSummaryAPIResponse struct {
+	Summary CaseSummary `json:"data"`
+	ApiMeta
+}
+
+// CaseSummary is the Markdown summary of a case with the CRC32 IRIS uses to detect concurrent edits
+type CaseSummary struct {
+	CaseDescription string `json:"case_description"`
+	CRC32           uint32 `json:"crc32"`
+}
+
+// Checksum returns the CRC32 of the summary text, computed locally so it does not depend on the server
+// filling the crc32 field
+func (summary CaseSummary) Checksum() uint32 {
+	return crc32.ChecksumIEEE([]byte(summary.CaseDescription))
+}
+
+// caseSummaryRequest represents the body of the /case/summary/update endpoint
+type caseSummaryRequest struct {
+	CaseDescription string `json:"case_description"`
+}
+
+// GetCaseSummary gets the Markdown summary of a case from the /case/summary/fetch endpoint.
+//
+// Returns:
+// - *CaseSummaryAPIResponse*: The response from the API containing the summary in the Summary field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetCaseSummary(caseId int) (*CaseSummaryAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/case/summary/fetch").
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var caseSummaryResponse CaseSummaryAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&caseSummaryResponse); err != nil {
+		return nil, err
+	}
+
+	return &caseSummaryResponse, nil
+}
+
+// SetCaseSummary overwrites the summary of a case through the /case/summary/update endpoint, whatever it
+// contains now. Unlike UpdateCase it can also clear the summary. Use UpdateCaseSummary or EditCaseSummary
+// to keep concurrent edits.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) SetCaseSummary(caseId int, summary string) error {
+	jsondata, err := json.Marshal(caseSummaryRequest{CaseDescription: summary})
+	if err != nil {
+		return err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/case/summary/update").
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return nil
+}
+
+// UpdateCaseSummary writes the summary of a case only if it still has the checksum read before. IRIS has
+// no conditional update, so the summary is fetched again right before writing; this narrows the window for
+// lost updates to a single request instead of closing it.
+//
+// Example usage:
+//
+//	current, _ := client.GetCaseSummary(42)
+//	text := goiris.AppendMarkdownSection(current.Summary.CaseDescription, "Automation", "- host isolated")
+//	err := client.UpdateCaseSummary(42, current.Summary.Checksum(), text)
+//	if errors.Is(err, goiris.ErrSummaryConflict) {
+//	    // an analyst edited the summary, read it again
+//	}
+//
+// Returns:
+// - error: ErrSummaryConflict if the summary changed, or an error if a request fails.
+func (client *APIClient) UpdateCaseSummary(caseId int, checksum uint32, summary string) error {
+	current, err := client.GetCaseSummary(caseId)
+	if err != nil {
+		return err
+	}
+	if current.Summary.Checksum() != checksum {
+		return fmt.Errorf("%w: case %d", ErrSummaryConflict, caseId)
+	}
+	if current.Summary.CaseDescription == summary {
+		return nil
+	}
+	return client.SetCaseSummary(caseId, summary)
+}
+
+// EditCaseSummary reads the summary of a case, passes it to edit and writes the result with
+// UpdateCaseSummary. On a conflict the edit is applied again to the new summary, up to attempts times.
+//
+// Example usage:
+//
+//	err := client.EditCaseSummary(42, 3, func(summary string) (string, error) {
+//	    return goiris.ReplaceMarkdownSection(summary, "Affected hosts", hostTable), nil
+//	})
+//
+// Returns:
+// - error: ErrSummaryConflict if every attempt conflicted, the error of edit, or an error if a request fails.
+func (client *APIClient) EditCaseSummary(caseId int, attempts int, edit func(summary string) (string, error)) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		var current *CaseSummaryAPIResponse
+		current, err = client.GetCaseSummary(caseId)
+		if err != nil {
+			return err
+		}
+		var summary string
+		summary, err = edit(current.Summary.CaseDescription)
+		if err != nil {
+			return err
+		}
+		err = client.UpdateCaseSummary(caseId, current.Summary.Checksum(), summary)
+		if !errors.Is(err, ErrSummaryConflict) {
+			return err
+		}
+	}
+	return err
+}
+
+var markdownHeading = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+
+// markdownSection is the position of a section in the lines of a document
+type markdownSection struct {
+	heading int // line of the heading
+	body    int // first line after the heading
+	end     int // first line of the next heading of the same or a higher level
+}
+
+// findMarkdownSection finds the first ATX heading with the text title, ignoring case and headings in code blocks
+func findMarkdownSection(lines []string, title string) (markdownSection, bool) {
+	section := markdownSection{heading: -1}
+	level := 0
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		match := markdownHeading.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if section.heading >= 0 {
+			if len(match[1]) <= level {
+				section.end = i
+				return section, true
+			}
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(match[2]), strings.TrimSpace(title)) {
+			section = markdownSection{heading: i, body: i + 1, end: len(lines)}
+			level = len(match[1])
+		}
+	}
+	return section, section.heading >= 0
+}
+
+// MarkdownSection returns the text below the heading title up to the next heading of the same or a higher
+// level, without the surrounding blank lines. Headings are matched ignoring case and level.
+func MarkdownSection(document, title string) (string, bool) {
+	lines := strings.Split(document, "\n")
+	section, ok := findMarkdownSection(lines, title)
+	if !ok {
+		return "", false
+	}
+	return strings.Trim(strings.Join(lines[section.body:section.end], "\n"), "\n"), true
+}
+
+// ReplaceMarkdownSection replaces the text of the section title, including its subsections, with body and
+// leaves the rest of the document untouched. A missing section is appended as a level 2 heading.
+func ReplaceMarkdownSection(document, title, body string) string {
+	lines := strings.Split(document, "\n")
+	section, ok := findMarkdownSection(lines, title)
+	if !ok {
+		return appendMarkdownSection(document, title, body)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(strings.Join(lines[:section.body], "\n"))
+	builder.WriteString("\n")
+	if body = strings.Trim(body, "\n"); body != "" {
+		builder.WriteString("\n" + body + "\n")
+	}
+	if section.end < len(lines) {
+		builder.WriteString("\n" + strings.Join(lines[section.end:], "\n"))
+	}
+	return builder.String()
+}
+
+// AppendMarkdownSection adds text at the end of the section title, after what analysts wrote there. A
+// missing section is appended as a level 2 heading.
+func AppendMarkdownSection(document, title, text string) string {
+	current, ok := MarkdownSection(document, title)
+	if !ok {
+		return appendMarkdownSection(document, title, text)
+	}
+	if text = strings.Trim(text, "\n"); current != "" {
+		text = current + "\n" + text
+	}
+	return ReplaceMarkdownSection(document, title, text)
+}
+
+// appendMarkdownSection adds a new level 2 section at the end of document
+func appendMarkdownSection(document, title, body string) string {
+	section := "## " + strings.TrimSpace(title) + "\n"
+	if body = strings.Trim(body, "\n"); body != "" {
+		section += "\n" + body + "\n"
+	}
+	if document = strings.TrimRight(document, "\n"); document == "" {
+		return section
+	}
+	return document + "\n\n" + section
+}
@@ -0,0 +1,189 @@
+package goiris
+
+import "testing"
+
+const summaryDocument = `# Incident
+
+Intro text.
+
+## Affected hosts
+
+- ws1
+
+### Details
+
+ws1 details
+
+## Timeline
+
+` + "```" + `
+## Affected hosts
+not a heading
+` + "```" + `
+Events.
+`
+
+func TestMarkdownSection(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+		found bool
+	}{
+		{"with subsection", "Affected hosts", "- ws1\n\n### Details\n\nws1 details", true},
+		{"case and spaces ignored", "  affected HOSTS ", "- ws1\n\n### Details\n\nws1 details", true},
+		{"subsection", "Details", "ws1 details", true},
+		{"last section with code block", "Timeline", "```\n## Affected hosts\nnot a heading\n```\nEvents.", true},
+		{"top level includes lower levels", "Incident", summaryDocument[len("# Incident\n\n") : len(summaryDocument)-1], true},
+		{"missing", "Containment", "", false},
+		{"heading in code block", "not a heading", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, found := MarkdownSection(summaryDocument, test.title)
+			if got != test.want || found != test.found {
+				t.Errorf("MarkdownSection(%q) = %q, %v, want %q, %v", test.title, got, found, test.want, test.found)
+			}
+		})
+	}
+}
+
+func TestMarkdownSectionHeadings(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		title    string
+		want     string
+	}{
+		{"closing hashes", "## Hosts ##\nws1\n## Next\n", "Hosts", "ws1"},
+		{"indented", "   ## Hosts\nws1\n", "Hosts", "ws1"},
+		{"lower level ends a higher section", "### Hosts\nws1\n## Next\nx\n", "Hosts", "ws1"},
+		{"tilde fence", "## Hosts\n~~~\n# x\n~~~\nws1\n# Next\n", "Hosts", "~~~\n# x\n~~~\nws1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got, _ := MarkdownSection(test.document, test.title); got != test.want {
+				t.Errorf("MarkdownSection = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestReplaceMarkdownSection(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		title    string
+		body     string
+		want     string
+	}{
+		{
+			name:     "middle section keeps the rest",
+			document: "# A\n\na\n\n## B\n\nold\n\n## C\n\nc\n",
+			title:    "b",
+			body:     "new\n",
+			want:     "# A\n\na\n\n## B\n\nnew\n\n## C\n\nc\n",
+		},
+		{
+			name:     "subsections are replaced",
+			document: "## B\n\nold\n\n### B1\n\nx\n\n## C\n",
+			title:    "B",
+			body:     "new",
+			want:     "## B\n\nnew\n\n## C\n",
+		},
+		{
+			name:     "last section",
+			document: "## A\n\na\n\n## B\n\nold\n",
+			title:    "B",
+			body:     "new",
+			want:     "## A\n\na\n\n## B\n\nnew\n",
+		},
+		{
+			name:     "empty body",
+			document: "## B\n\nold\n\n## C\n",
+			title:    "B",
+			body:     "",
+			want:     "## B\n\n## C\n",
+		},
+		{
+			name:     "missing section is appended",
+			document: "# A\n\na\n\n\n",
+			title:    "Hosts",
+			body:     "ws1",
+			want:     "# A\n\na\n\n## Hosts\n\nws1\n",
+		},
+		{
+			name:     "empty document",
+			document: "",
+			title:    "Hosts",
+			body:     "ws1",
+			want:     "## Hosts\n\nws1\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ReplaceMarkdownSection(test.document, test.title, test.body); got != test.want {
+				t.Errorf("ReplaceMarkdownSection =\n%q\nwant\n%q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAppendMarkdownSection(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		title    string
+		text     string
+		want     string
+	}{
+		{
+			name:     "after the existing text",
+			document: "## Log\n\n- one\n\n## Other\n",
+			title:    "Log",
+			text:     "- two\n",
+			want:     "## Log\n\n- one\n- two\n\n## Other\n",
+		},
+		{
+			name:     "empty section",
+			document: "## Log\n\n## Other\n",
+			title:    "Log",
+			text:     "- one",
+			want:     "## Log\n\n- one\n\n## Other\n",
+		},
+		{
+			name:     "missing section",
+			document: "Summary.",
+			title:    "Log",
+			text:     "- one",
+			want:     "Summary.\n\n## Log\n\n- one\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := AppendMarkdownSection(test.document, test.title, test.text); got != test.want {
+				t.Errorf("AppendMarkdownSection =\n%q\nwant\n%q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCaseSummaryChecksum(t *testing.T) {
+	tests := []struct {
+		summary string
+		want    uint32
+	}{
+		{"", 0},
+		{"123456789", 0xcbf43926},
+	}
+
+	for _, test := range tests {
+		if got := (CaseSummary{CaseDescription: test.summary}).Checksum(); got != test.want {
+			t.Errorf("Checksum(%q) = %#x, want %#x", test.summary, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package goiris
+
+// CaseTemplateBuilder builds a CaseTemplate step by step
+type CaseTemplateBuilder struct {
+	template CaseTemplate
+}
+
+// NewCaseTemplateBuilder returns a builder for a template with the given unique name
+func NewCaseTemplateBuilder(name string) *CaseTemplateBuilder {
+	return &CaseTemplateBuilder{
+		template: CaseTemplate{Name: name},
+	}
+}
+
+// SetDisplayName sets the name shown in the template selection of IRIS
+func (tb *CaseTemplateBuilder) SetDisplayName(displayName string) *CaseTemplateBuilder {
+	tb.template.DisplayName = displayName
+	return tb
+}
+
+// SetDescription sets the description of the template
+func (tb *CaseTemplateBuilder) SetDescription(description string) *CaseTemplateBuilder {
+	tb.template.Description = description
+	return tb
+}
+
+// SetAuthor sets the author of the template
+func (tb *CaseTemplateBuilder) SetAuthor(author string) *CaseTemplateBuilder {
+	tb.template.Author = author
+	return tb
+}
+
+// SetClassification sets the classification by name, e.g. "malicious-code:ransomware"
+func (tb *CaseTemplateBuilder) SetClassification(classification string) *CaseTemplateBuilder {
+	tb.template.Classification = classification
+	return tb
+}
+
+// SetTitlePrefix sets the prefix put in front of the name of cases using the template
+func (tb *CaseTemplateBuilder) SetTitlePrefix(titlePrefix string) *CaseTemplateBuilder {
+	tb.template.TitlePrefix = titlePrefix
+	return tb
+}
+
+// SetSummary sets the summary of cases using the template
+func (tb *CaseTemplateBuilder) SetSummary(summary string) *CaseTemplateBuilder {
+	tb.template.Summary = summary
+	return tb
+}
+
+// AddTags adds tags to cases using the template
+func (tb *CaseTemplateBuilder) AddTags(tags ...string) *CaseTemplateBuilder {
+	tb.template.Tags = append(tb.template.Tags, tags...)
+	return tb
+}
+
+// AddTask adds a task created with every case using the template
+func (tb *CaseTemplateBuilder) AddTask(title, description string, tags ...string) *CaseTemplateBuilder {
+	tb.template.Tasks = append(tb.template.Tasks, CaseTemplateTask{
+		Title:       title,
+		Description: description,
+		Tags:        tags,
+	})
+	return tb
+}
+
+// AddNoteDirectory adds a note directory, notes can be added with AddNote afterwards
+func (tb *CaseTemplateBuilder) AddNoteDirectory(title string, notes ...CaseTemplateNote) *CaseTemplateBuilder {
+	tb.template.NoteDirectories = append(tb.template.NoteDirectories, CaseTemplateNoteDirectory{
+		Title: title,
+		Notes: notes,
+	})
+	return tb
+}
+
+// AddNote adds a note to the directory with the given title. The directory is created if it does not exist yet.
+func (tb *CaseTemplateBuilder) AddNote(directory, title, content string) *CaseTemplateBuilder {
+	note := CaseTemplateNote{Title: title, Content: content}
+	for i := range tb.template.NoteDirectories {
+		if tb.template.NoteDirectories[i].Title == directory {
+			tb.template.NoteDirectories[i].Notes = append(tb.template.NoteDirectories[i].Notes, note)
+			return tb
+		}
+	}
+
+	return tb.AddNoteDirectory(directory, note)
+}
+
+// Build returns the template. It shares no slices with the builder, so the builder can be used further.
+func (tb *CaseTemplateBuilder) Build() CaseTemplate {
+	template := tb.template
+	template.Tags = copyStrings(template.Tags)
+
+	template.Tasks = append([]CaseTemplateTask(nil), template.Tasks...)
+	for i := range template.Tasks {
+		template.Tasks[i].Tags = copyStrings(template.Tasks[i].Tags)
+	}
+
+	template.NoteDirectories = append([]CaseTemplateNoteDirectory(nil), template.NoteDirectories...)
+	for i := range template.NoteDirectories {
+		template.NoteDirectories[i].Notes = append([]CaseTemplateNote(nil), template.NoteDirectories[i].Notes...)
+	}
+	return template
+}
+
+func copyStrings(values []string) []string {
+	return append([]string(nil), values...)
+}
+
+// JSON builds the template and returns it as case_template_json string
+func (tb *CaseTemplateBuilder) JSON() (string, error) {
+	return tb.Build().Marshal()
+}
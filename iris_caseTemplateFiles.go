@@ -0,0 +1,399 @@
+package goiris
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CaseTemplateFileFormat is the on-disk format of exported case templates
+type CaseTemplateFileFormat string
+
+const (
+	CaseTemplateJSON CaseTemplateFileFormat = "json"
+	CaseTemplateYAML CaseTemplateFileFormat = "yaml"
+)
+
+// Actions reported by ImportCaseTemplates
+const (
+	CaseTemplateCreated   = "created"
+	CaseTemplateUpdated   = "updated"
+	CaseTemplateUnchanged = "unchanged"
+	CaseTemplateInvalid   = "invalid"
+	CaseTemplateFailed    = "failed"
+)
+
+// CaseTemplateImportResult is the outcome for a single template file
+type CaseTemplateImportResult struct {
+	File   string
+	Name   string
+	Action string
+	Diff   []string
+	Err    error
+}
+
+// CaseTemplateImportReport contains the result of every template file found by ImportCaseTemplates
+type CaseTemplateImportReport struct {
+	DryRun  bool
+	Results []CaseTemplateImportResult
+}
+
+// Err returns the errors of all invalid or failed templates joined together, nil if there were none
+func (report *CaseTemplateImportReport) Err() error {
+	var errs []error
+	for _, result := range report.Results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.File, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var templateFileNameReplacer = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// ValidateCaseTemplate checks template against the constraints IRIS enforces on case_template_json.
+// All problems are returned at once.
+func ValidateCaseTemplate(template CaseTemplate) error {
+	var errs []error
+
+	if strings.TrimSpace(template.Name) == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	for i, tag := range template.Tags {
+		if strings.TrimSpace(tag) == "" {
+			errs = append(errs, fmt.Errorf("tags[%d] is empty", i))
+		}
+	}
+	for i, task := range template.Tasks {
+		if strings.TrimSpace(task.Title) == "" {
+			errs = append(errs, fmt.Errorf("tasks[%d]: title is required", i))
+		}
+	}
+	for i, directory := range template.NoteDirectories {
+		if strings.TrimSpace(directory.Title) == "" {
+			errs = append(errs, fmt.Errorf("note_directories[%d]: title is required", i))
+		}
+		for j, note := range directory.Notes {
+			if strings.TrimSpace(note.Title) == "" {
+				errs = append(errs, fmt.Errorf("note_directories[%d].notes[%d]: title is required", i, j))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DiffCaseTemplates returns a human readable line for every field that differs between current and desired.
+// Server managed fields are ignored, an empty result means both templates are equal.
+func DiffCaseTemplates(current, desired CaseTemplate) []string {
+	var diff []string
+
+	diffString := func(field, a, b string) {
+		if a != b {
+			diff = append(diff, fmt.Sprintf("%s: %q -> %q", field, a, b))
+		}
+	}
+
+	diffString("name", current.Name, desired.Name)
+	diffString("display_name", current.DisplayName, desired.DisplayName)
+	diffString("description", current.Description, desired.Description)
+	diffString("author", current.Author, desired.Author)
+	diffString("classification", current.Classification, desired.Classification)
+	diffString("title_prefix", current.TitlePrefix, desired.TitlePrefix)
+	diffString("summary", current.Summary, desired.Summary)
+
+	if !slices.Equal(current.Tags, desired.Tags) {
+		diff = append(diff, fmt.Sprintf("tags: %v -> %v", current.Tags, desired.Tags))
+	}
+
+	for i := 0; i < max(len(current.Tasks), len(desired.Tasks)); i++ {
+		field := fmt.Sprintf("tasks[%d]", i)
+		switch {
+		case i >= len(current.Tasks):
+			diff = append(diff, fmt.Sprintf("%s: added %q", field, desired.Tasks[i].Title))
+		case i >= len(desired.Tasks):
+			diff = append(diff, fmt.Sprintf("%s: removed %q", field, current.Tasks[i].Title))
+		default:
+			diffString(field+".title", current.Tasks[i].Title, desired.Tasks[i].Title)
+			diffString(field+".description", current.Tasks[i].Description, desired.Tasks[i].Description)
+			if !slices.Equal(current.Tasks[i].Tags, desired.Tasks[i].Tags) {
+				diff = append(diff, fmt.Sprintf("%s.tags: %v -> %v", field, current.Tasks[i].Tags, desired.Tasks[i].Tags))
+			}
+		}
+	}
+
+	for i := 0; i < max(len(current.NoteDirectories), len(desired.NoteDirectories)); i++ {
+		field := fmt.Sprintf("note_directories[%d]", i)
+		switch {
+		case i >= len(current.NoteDirectories):
+			diff = append(diff, fmt.Sprintf("%s: added %q", field, desired.NoteDirectories[i].Title))
+		case i >= len(desired.NoteDirectories):
+			diff = append(diff, fmt.Sprintf("%s: removed %q", field, current.NoteDirectories[i].Title))
+		default:
+			currentDirectory, desiredDirectory := current.NoteDirectories[i], desired.NoteDirectories[i]
+			diffString(field+".title", currentDirectory.Title, desiredDirectory.Title)
+			for j := 0; j < max(len(currentDirectory.Notes), len(desiredDirectory.Notes)); j++ {
+				noteField := fmt.Sprintf("%s.notes[%d]", field, j)
+				switch {
+				case j >= len(currentDirectory.Notes):
+					diff = append(diff, fmt.Sprintf("%s: added %q", noteField, desiredDirectory.Notes[j].Title))
+				case j >= len(desiredDirectory.Notes):
+					diff = append(diff, fmt.Sprintf("%s: removed %q", noteField, currentDirectory.Notes[j].Title))
+				default:
+					diffString(noteField+".title", currentDirectory.Notes[j].Title, desiredDirectory.Notes[j].Title)
+					diffString(noteField+".content", currentDirectory.Notes[j].Content, desiredDirectory.Notes[j].Content)
+				}
+			}
+		}
+	}
+
+	return diff
+}
+
+// LoadCaseTemplateFile reads a single template from a .json, .yaml or .yml file
+func LoadCaseTemplateFile(path string) (CaseTemplate, error) {
+	var template CaseTemplate
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return template, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &template)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &template)
+	default:
+		err = fmt.Errorf("unsupported template file extension: %s", filepath.Ext(path))
+	}
+
+	return template, err
+}
+
+// WriteCaseTemplateFile writes template to path in the given format. Server managed fields are not written.
+func WriteCaseTemplateFile(path string, template CaseTemplate, format CaseTemplateFileFormat) error {
+	template.ID = 0
+	template.CreatedAt = ""
+	template.UpdatedAt = ""
+	template.AddedBy = ""
+
+	var data []byte
+	var err error
+	switch format {
+	case CaseTemplateJSON:
+		data, err = json.MarshalIndent(template, "", "  ")
+		data = append(data, '\n')
+	case CaseTemplateYAML:
+		data, err = yaml.Marshal(template)
+	default:
+		err = fmt.Errorf("unsupported template file format: %s", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ExportCaseTemplates writes every case template of the instance into dir, one file per template
+// named after the template name. Templates whose file names would collide, because of duplicate names or
+// characters replaced in the name, all get their ID appended, again until every name is unique, so the
+// names do not depend on the order of the templates. The directory is created if it does not exist.
+//
+// Example usage:
+//
+//	files, err := client.ExportCaseTemplates("playbooks", goiris.CaseTemplateYAML)
+//	if err != nil {
+//	    log.Fatalf("Failed to export templates: %v", err)
+//	}
+//
+// Returns:
+// - []string: The paths of the written files.
+// - error: An error if a request fails or a file cannot be written.
+func (client *APIClient) ExportCaseTemplates(dir string, format CaseTemplateFileFormat) ([]string, error) {
+	if format != CaseTemplateJSON && format != CaseTemplateYAML {
+		return nil, fmt.Errorf("unsupported template file format: %s", format)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	templates, err := client.ListCaseTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	names := caseTemplateFileNames(templates.CaseTemplates)
+
+	var files []string
+	for i, listed := range templates.CaseTemplates {
+		templateResponse, err := client.GetCaseTemplate(listed.ID)
+		if err != nil {
+			return files, fmt.Errorf("template %d: %w", listed.ID, err)
+		}
+
+		path := filepath.Join(dir, names[i]+"."+string(format))
+		if err := WriteCaseTemplateFile(path, templateResponse.CaseTemplate, format); err != nil {
+			return files, err
+		}
+		files = append(files, path)
+	}
+
+	return files, nil
+}
+
+// caseTemplateFileNames returns a unique file name for each of templates. Names are compared ignoring
+// case, the directory may be on a case insensitive file system. Every template of a colliding group gets
+// its ID appended, until no names collide anymore; a suffixed name can match the name of another template.
+func caseTemplateFileNames(templates []CaseTemplate) []string {
+	names := make([]string, len(templates))
+	for i, template := range templates {
+		names[i] = caseTemplateFileName(template.Name)
+	}
+
+	for {
+		groups := make(map[string][]int)
+		for i, name := range names {
+			groups[strings.ToLower(name)] = append(groups[strings.ToLower(name)], i)
+		}
+
+		collided := false
+		for _, group := range groups {
+			if len(group) < 2 {
+				continue
+			}
+			collided = true
+			for _, i := range group {
+				names[i] = fmt.Sprintf("%s_%d", names[i], templates[i].ID)
+			}
+		}
+		if !collided {
+			return names
+		}
+	}
+}
+
+// caseTemplateFileName returns the template name with every character unsafe in file names replaced
+func caseTemplateFileName(name string) string {
+	return templateFileNameReplacer.ReplaceAllString(name, "_")
+}
+
+// ImportCaseTemplates reads every .json, .yaml and .yml file in dir, validates it and creates or
+// updates the template with the same name on the instance. Templates without changes are left alone,
+// so importing the same directory twice is a no-op. With dryRun nothing is uploaded and the report only
+// shows what would happen.
+//
+// Invalid templates and failed uploads do not stop the import, they are recorded in the report and
+// returned together by CaseTemplateImportReport.Err.
+//
+// Example usage:
+//
+//	report, err := client.ImportCaseTemplates("playbooks", true)
+//	if err != nil {
+//	    log.Fatalf("Failed to import templates: %v", err)
+//	}
+//	for _, result := range report.Results {
+//		fmt.Println(result.Name, result.Action, strings.Join(result.Diff, "\n"))
+//	}
+//
+// Returns:
+// - *CaseTemplateImportReport*: The outcome of every template file.
+// - error: An error if the directory cannot be read or the existing templates cannot be fetched.
+func (client *APIClient) ImportCaseTemplates(dir string, dryRun bool) (*CaseTemplateImportReport, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := client.ListCaseTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]int)
+	for _, template := range templates.CaseTemplates {
+		existing[template.Name] = template.ID
+	}
+
+	report := &CaseTemplateImportReport{DryRun: dryRun}
+	seen := make(map[string]string)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json", ".yaml", ".yml":
+		default:
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		result := CaseTemplateImportResult{File: path}
+
+		template, err := LoadCaseTemplateFile(path)
+		result.Name = template.Name
+		if err == nil {
+			err = ValidateCaseTemplate(template)
+		}
+		if err == nil && seen[template.Name] != "" {
+			err = fmt.Errorf("duplicate template name %q, already defined in %s", template.Name, seen[template.Name])
+		}
+		if err != nil {
+			result.Action = CaseTemplateInvalid
+			result.Err = err
+			report.Results = append(report.Results, result)
+			continue
+		}
+		seen[template.Name] = path
+
+		templateId, exists := existing[template.Name]
+		if !exists {
+			result.Action = CaseTemplateCreated
+			result.Diff = DiffCaseTemplates(CaseTemplate{}, template)
+			if !dryRun {
+				if _, err := client.AddCaseTemplateObject(template); err != nil {
+					result.Action = CaseTemplateFailed
+					result.Err = err
+				}
+			}
+			report.Results = append(report.Results, result)
+			continue
+		}
+
+		current, err := client.GetCaseTemplate(templateId)
+		if err != nil {
+			result.Action = CaseTemplateFailed
+			result.Err = err
+			report.Results = append(report.Results, result)
+			continue
+		}
+
+		result.Diff = DiffCaseTemplates(current.CaseTemplate, template)
+		if len(result.Diff) == 0 {
+			result.Action = CaseTemplateUnchanged
+			report.Results = append(report.Results, result)
+			continue
+		}
+
+		result.Action = CaseTemplateUpdated
+		if !dryRun {
+			if _, err := client.UpdateCaseTemplateObject(templateId, template); err != nil {
+				result.Action = CaseTemplateFailed
+				result.Err = err
+			}
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
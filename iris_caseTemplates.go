@@ -6,31 +6,153 @@ import (
 	"net/http"
 )
 
-// AddCaseTemplateResponse represents the response of Add a case template resonse
+// CaseTemplateAPIResponse represents the response of a single case template api action
 type CaseTemplateAPIResponse struct {
-	CaseTemplate CaseTemplateResponse `json:"data"`
+	CaseTemplate CaseTemplate `json:"data"`
 	ApiMeta
 }
 
-// CaseTemplateResponse contains the response of a case template api action
-type CaseTemplateResponse struct {
-	Registry             any    `json:"registry"`
-	TypeDescription      string `json:"type_description"`
-	TypeID               int    `json:"type_id"`
-	TypeName             string `json:"type_name"`
-	TypeTaxonomy         string `json:"type_taxonomy"`
-	TypeValidationExpect string `json:"type_validation_expect"`
-	TypeValidationRegex  string `json:"type_validation_regex"`
+// CaseTemplatesResponse represents the response of the case templates list endpoint
+type CaseTemplatesResponse struct {
+	CaseTemplates []CaseTemplate `json:"data"`
+	ApiMeta
+}
+
+// CaseTemplate represents a case template as stored by IRIS.
+// The server managed fields (ID, dates, AddedBy) are omitted when marshalled
+// into a case_template_json payload.
+type CaseTemplate struct {
+	ID              int                         `json:"id,omitempty" yaml:"-"`
+	CreatedAt       string                      `json:"created_at,omitempty" yaml:"-"`
+	UpdatedAt       string                      `json:"updated_at,omitempty" yaml:"-"`
+	AddedBy         string                      `json:"added_by,omitempty" yaml:"-"`
+	Name            string                      `json:"name" yaml:"name"`
+	DisplayName     string                      `json:"display_name,omitempty" yaml:"display_name,omitempty"`
+	Description     string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Author          string                      `json:"author,omitempty" yaml:"author,omitempty"`
+	Classification  string                      `json:"classification,omitempty" yaml:"classification,omitempty"`
+	TitlePrefix     string                      `json:"title_prefix,omitempty" yaml:"title_prefix,omitempty"`
+	Summary         string                      `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Tags            []string                    `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Tasks           []CaseTemplateTask          `json:"tasks,omitempty" yaml:"tasks,omitempty"`
+	NoteDirectories []CaseTemplateNoteDirectory `json:"note_directories,omitempty" yaml:"note_directories,omitempty"`
+}
+
+// CaseTemplateTask represents a task created with every case using the template
+type CaseTemplateTask struct {
+	Title       string   `json:"title" yaml:"title"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// CaseTemplateNoteDirectory represents a note directory and its notes created with every case using the template
+type CaseTemplateNoteDirectory struct {
+	Title string             `json:"title" yaml:"title"`
+	Notes []CaseTemplateNote `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// CaseTemplateNote represents a single note of a note directory
+type CaseTemplateNote struct {
+	Title   string `json:"title" yaml:"title"`
+	Content string `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// Marshal returns the template as a string suitable for the case_template_json field.
+// Server managed fields are left out.
+func (template CaseTemplate) Marshal() (string, error) {
+	template.ID = 0
+	template.CreatedAt = ""
+	template.UpdatedAt = ""
+	template.AddedBy = ""
+
+	jsondata, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsondata), nil
 }
 
+// ListCaseTemplates gets a list of all case templates from the /manage/case-templates/list endpoint.
+// The list endpoint only returns the template metadata, use GetCaseTemplate to get the tasks and notes.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Example usage:
+//
+//	templates, err := client.ListCaseTemplates()
+//	if err != nil {
+//	    log.Fatalf("Failed to list case templates: %v", err)
+//	}
+//	for _, template := range templates.CaseTemplates {
+//		fmt.Println(template.ID, template.Name)
+//	}
+//
+// Returns:
+// - *CaseTemplatesResponse*: The response from the API containing the templates in the CaseTemplates field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListCaseTemplates() (*CaseTemplatesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/case-templates/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var templatesResponse CaseTemplatesResponse
+	if err := json.NewDecoder(req.Body).Decode(&templatesResponse); err != nil {
+		return nil, err
+	}
+
+	return &templatesResponse, nil
+}
+
+// GetCaseTemplate gets a single case template including its tasks and notes from the /manage/case-templates/<id> endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *CaseTemplateAPIResponse*: The response from the API containing the template in the CaseTemplate field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetCaseTemplate(templateId int) (*CaseTemplateAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/case-templates/%d", templateId)).
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var templateResponse CaseTemplateAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&templateResponse); err != nil {
+		return nil, err
+	}
+
+	return &templateResponse, nil
+}
 
-// AddCaseTemplate adds a case template using the /manag/case-templates/add endpoint
-// It returns a AddCaseTemplateResponse 
+// AddCaseTemplate adds a case template using the /manage/case-templates/add endpoint.
+// The caseTemplate argument is the raw case_template_json, see AddCaseTemplateObject for the typed variant.
 // If the request fails or the response cannot be decoded,
 // an error is returned.
 //
 // Returns:
-// - *AddCaseTemplateResponse*: The response from the API containing the template informations in the CaseTemplate field.
+// - *CaseTemplateAPIResponse*: The response from the API containing the template informations in the CaseTemplate field.
 // - error: An error if the request fails or the response cannot be decoded.
 func (client *APIClient) AddCaseTemplate(caseTemplate string) (*CaseTemplateAPIResponse, error) {
 	jsondata, err := json.Marshal(map[string]string{"case_template_json": caseTemplate})
@@ -51,23 +173,20 @@ func (client *APIClient) AddCaseTemplate(caseTemplate string) (*CaseTemplateAPIR
 	}
 	defer req.Body.Close()
 
-
 	if req.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
 	}
 
 	var templateResponse CaseTemplateAPIResponse
 	if err := json.NewDecoder(req.Body).Decode(&templateResponse); err != nil {
-		fmt.Println(err)
 		return nil, err
 	}
 
 	return &templateResponse, nil
 }
 
-
-// UpdateCaseTemplate updates a case template using the /manag/case-templates/update/ endpoint
-// It returns a AddCaseTemplateResponse 
+// UpdateCaseTemplate updates a case template using the /manage/case-templates/update/<id> endpoint.
+// The caseTemplate argument is the raw case_template_json, see UpdateCaseTemplateObject for the typed variant.
 // If the request fails or the response cannot be decoded,
 // an error is returned.
 //
@@ -81,7 +200,7 @@ func (client *APIClient) UpdateCaseTemplate(templateId int, caseTemplate string)
 	}
 
 	builder := NewRequestBuilder().
-		SetURL(fmt.Sprintf("/manage/case-templates/update/%d",templateId)).
+		SetURL(fmt.Sprintf("/manage/case-templates/update/%d", templateId)).
 		SetMethod(http.MethodPost).
 		AddHeader("Content-Type", "application/json").
 		SetBody(jsondata).
@@ -93,29 +212,63 @@ func (client *APIClient) UpdateCaseTemplate(templateId int, caseTemplate string)
 	}
 	defer req.Body.Close()
 
-
 	if req.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
 	}
 
 	var templateResponse CaseTemplateAPIResponse
 	if err := json.NewDecoder(req.Body).Decode(&templateResponse); err != nil {
-		fmt.Println(err)
 		return nil, err
 	}
 
 	return &templateResponse, nil
 }
 
-// DeleteCaseTemplate removes a case template using the /manage/case-templates/delete endpoint
-// If the request fails or the response cannot be decoded,
+// AddCaseTemplateObject marshals template and adds it using AddCaseTemplate.
+//
+// Example usage:
+//
+//	template := goiris.NewCaseTemplateBuilder("ransomware").
+//		SetDisplayName("Ransomware").
+//		AddTask("Isolate hosts", "Isolate all affected hosts", "containment").
+//		Build()
+//	response, err := client.AddCaseTemplateObject(template)
+//
+// Returns:
+// - *CaseTemplateAPIResponse*: The response from the API containing the template informations in the CaseTemplate field.
+// - error: An error if the template cannot be marshalled, the request fails or the response cannot be decoded.
+func (client *APIClient) AddCaseTemplateObject(template CaseTemplate) (*CaseTemplateAPIResponse, error) {
+	caseTemplate, err := template.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.AddCaseTemplate(caseTemplate)
+}
+
+// UpdateCaseTemplateObject marshals template and updates the template templateId using UpdateCaseTemplate.
+//
+// Returns:
+// - *CaseTemplateAPIResponse*: The response from the API containing the template informations in the CaseTemplate field.
+// - error: An error if the template cannot be marshalled, the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateCaseTemplateObject(templateId int, template CaseTemplate) (*CaseTemplateAPIResponse, error) {
+	caseTemplate, err := template.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.UpdateCaseTemplate(templateId, caseTemplate)
+}
+
+// DeleteCaseTemplate removes a case template using the /manage/case-templates/delete endpoint.
+// If the request fails or the status code is not 200,
 // an error is returned.
 //
 // Returns:
-// - error: An error if the request fails or the response cannot be decoded.
+// - error: An error if the request fails or the status code is not 200.
 func (client *APIClient) DeleteCaseTemplate(templateId int) error {
 	builder := NewRequestBuilder().
-		SetURL(fmt.Sprintf("/manage/case-templates/delete/%d",templateId)).
+		SetURL(fmt.Sprintf("/manage/case-templates/delete/%d", templateId)).
 		SetMethod(http.MethodPost).
 		AddHeader("Content-Type", "application/json").
 		Build()
@@ -126,7 +279,9 @@ func (client *APIClient) DeleteCaseTemplate(templateId int) error {
 	}
 	defer req.Body.Close()
 
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
 
-	return err
+	return nil
 }
-
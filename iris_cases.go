@@ -0,0 +1,306 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// CasesResponse represents the response of the /manage/cases/list endpoint
+type CasesResponse struct {
+	Cases []Case `json:"data"`
+	ApiMeta
+}
+
+// CaseAPIResponse represents the response of a single case api action
+type CaseAPIResponse struct {
+	Case Case `json:"data"`
+	ApiMeta
+}
+
+// Case represents a single case. Depending on the endpoint either the IDs or the names of
+// the referenced customer, state and classification are filled.
+type Case struct {
+	CaseID           int              `json:"case_id"`
+	CaseUUID         string           `json:"case_uuid"`
+	CaseName         string           `json:"case_name"`
+	CaseDescription  string           `json:"case_description"`
+	CaseSocID        string           `json:"case_soc_id"`
+	CaseOpenDate     string           `json:"case_open_date"`
+	CaseCloseDate    string           `json:"case_close_date"`
+	CaseTags         string           `json:"case_tags"`
+	ClientID         int              `json:"client_id"`
+	ClientName       string           `json:"client_name"`
+	ClassificationID int              `json:"classification_id"`
+	Classification   string           `json:"classification"`
+	StateID          int              `json:"state_id"`
+	StateName        string           `json:"state_name"`
+	SeverityID       int              `json:"severity_id"`
+	OwnerID          int              `json:"owner_id"`
+	OpenedBy         string           `json:"opened_by"`
+	OpenedByUserID   int              `json:"opened_by_user_id"`
+	ClosingNote      string           `json:"closing_note"`
+	CustomAttributes CustomAttributes `json:"custom_attributes"`
+}
+
+// AddCaseRequest represents a struct for opening a new case. CaseTemplateID is optional.
+type AddCaseRequest struct {
+	CaseCustomer     int             `json:"case_customer"`
+	CaseName         string          `json:"case_name"`
+	CaseDescription  string          `json:"case_description"`
+	CaseSocID        string          `json:"case_soc_id"`
+	ClassificationID int             `json:"classification_id,omitempty"`
+	CaseTemplateID   int             `json:"case_template_id,omitempty"`
+	CustomAttributes AttributeValues `json:"custom_attributes,omitempty"`
+}
+
+// UpdateCaseRequest represents a struct for updating an existing case, zero fields are left unchanged
+type UpdateCaseRequest struct {
+	CaseName         string          `json:"case_name,omitempty"`
+	CaseDescription  string          `json:"case_description,omitempty"`
+	CaseSocID        string          `json:"case_soc_id,omitempty"`
+	CaseCustomer     int             `json:"case_customer,omitempty"`
+	ClassificationID int             `json:"classification_id,omitempty"`
+	StateID          int             `json:"state_id,omitempty"`
+	SeverityID       int             `json:"severity_id,omitempty"`
+	OwnerID          int             `json:"owner_id,omitempty"`
+	CaseTags         string          `json:"case_tags,omitempty"`
+	CustomAttributes AttributeValues `json:"custom_attributes,omitempty"`
+}
+
+// ListCases gets a list of all cases the user has access to from the /manage/cases/list endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Example usage:
+//
+//	cases, err := client.ListCases()
+//	if err != nil {
+//	    log.Fatalf("Failed to list cases: %v", err)
+//	}
+//	for _, c := range cases.Cases {
+//		fmt.Println(c.CaseID, c.CaseName)
+//	}
+//
+// Returns:
+// - *CasesResponse*: The response from the API containing the cases in the Cases field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListCases() (*CasesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/cases/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var casesResponse CasesResponse
+	if err := json.NewDecoder(req.Body).Decode(&casesResponse); err != nil {
+		return nil, err
+	}
+
+	return &casesResponse, nil
+}
+
+// GetCase returns a single case from the /manage/cases/<case-id> endpoint.
+//
+// Returns:
+// - *CaseAPIResponse*: The response from the API containing the case in the Case field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetCase(caseId int) (*CaseAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/cases/%d", caseId)).
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var caseResponse CaseAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&caseResponse); err != nil {
+		return nil, err
+	}
+
+	return &caseResponse, nil
+}
+
+// AddCase opens a case through the /manage/cases/add endpoint.
+//
+// Example usage:
+//
+//	classificationId, _ := client.Lookup().CaseClassificationID("malicious-code:ransomware")
+//	c, err := client.AddCase(goiris.AddCaseRequest{
+//		CaseCustomer:     1,
+//		CaseName:         "Ransomware at ACME",
+//		CaseDescription:  "Encrypted file shares",
+//		CaseSocID:        "SOC-1234",
+//		ClassificationID: classificationId,
+//	})
+//
+// Returns:
+// - *CaseAPIResponse*: The response from the API containing the created case in the Case field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddCase(newCase AddCaseRequest) (*CaseAPIResponse, error) {
+	jsondata, err := json.Marshal(newCase)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/manage/cases/add").
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var caseResponse CaseAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&caseResponse); err != nil {
+		return nil, err
+	}
+
+	return &caseResponse, nil
+}
+
+// UpdateCase updates a case through the /manage/cases/update/<case-id> endpoint.
+//
+// Returns:
+// - *CaseAPIResponse*: The response from the API containing the updated case in the Case field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateCase(caseId int, update UpdateCaseRequest) (*CaseAPIResponse, error) {
+	jsondata, err := json.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/cases/update/%d", caseId)).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var caseResponse CaseAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&caseResponse); err != nil {
+		return nil, err
+	}
+
+	return &caseResponse, nil
+}
+
+// DeleteCase removes a case and all its objects using the /manage/cases/delete/<case-id> endpoint.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) DeleteCase(caseId int) error {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/cases/delete/%d", caseId)).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return nil
+}
+
+// CloseCase closes a case through the /manage/cases/close/<case-id> endpoint.
+//
+// Returns:
+// - *CaseAPIResponse*: The response from the API containing the closed case in the Case field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) CloseCase(caseId int) (*CaseAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/cases/close/%d", caseId)).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var caseResponse CaseAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&caseResponse); err != nil {
+		return nil, err
+	}
+
+	return &caseResponse, nil
+}
+
+// ReopenCase reopens a closed case through the /manage/cases/reopen/<case-id> endpoint.
+//
+// Returns:
+// - *CaseAPIResponse*: The response from the API containing the reopened case in the Case field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ReopenCase(caseId int) (*CaseAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/cases/reopen/%d", caseId)).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var caseResponse CaseAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&caseResponse); err != nil {
+		return nil, err
+	}
+
+	return &caseResponse, nil
+}
@@ -0,0 +1,178 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// CommentKind is the kind of case object a comment is attached to, it is the URL segment IRIS uses for the object
+type CommentKind string
+
+const (
+	CommentOnAsset    CommentKind = "assets"
+	CommentOnIoc      CommentKind = "ioc"
+	CommentOnEvent    CommentKind = "timeline/events"
+	CommentOnTask     CommentKind = "tasks"
+	CommentOnNote     CommentKind = "notes"
+	CommentOnEvidence CommentKind = "evidences"
+)
+
+// CommentKinds lists every kind of case object that can be commented
+var CommentKinds = []CommentKind{
+	CommentOnAsset,
+	CommentOnIoc,
+	CommentOnEvent,
+	CommentOnTask,
+	CommentOnNote,
+	CommentOnEvidence,
+}
+
+// CommentsResponse represents the response of the /case/<kind>/<id>/comments/list endpoint
+type CommentsResponse struct {
+	Comments []Comment `json:"data"`
+	ApiMeta
+}
+
+// Comment represents a comment on a case object
+type Comment struct {
+	CommentID         int    `json:"comment_id"`
+	CommentUUID       string `json:"comment_uuid"`
+	CommentText       string `json:"comment_text"`
+	CommentDate       string `json:"comment_date"`
+	CommentUpdateDate string `json:"comment_update_date"`
+	Name              string `json:"name"`
+	User              string `json:"user"`
+}
+
+// ListComments gets the comments of a case object from the /case/<kind>/<object-id>/comments/list endpoint.
+//
+// Example usage:
+//
+//	comments, err := client.ListComments(goiris.CommentOnIoc, 42, iocId)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Returns:
+// - *CommentsResponse*: The response from the API containing the comments in the Comments field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListComments(kind CommentKind, caseId int, objectId int) (*CommentsResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/%s/%d/comments/list", kind, objectId)).
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var commentsResponse CommentsResponse
+	if err := json.NewDecoder(req.Body).Decode(&commentsResponse); err != nil {
+		return nil, err
+	}
+
+	return &commentsResponse, nil
+}
+
+// CommentAPIResponse represents the response of a single comment api action
+type CommentAPIResponse struct {
+	Comment Comment `json:"data"`
+	ApiMeta
+}
+
+// CommentRequest represents a struct for adding or editing a comment
+type CommentRequest struct {
+	CommentText string `json:"comment_text"`
+}
+
+// AddComment comments a case object through the /case/<kind>/<object-id>/comments/add endpoint.
+//
+// Example usage:
+//
+//	comment, err := client.AddComment(goiris.CommentOnIoc, 42, iocId, "Seen in 3 other cases")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Returns:
+// - *CommentAPIResponse*: The response from the API containing the new comment in the Comment field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddComment(kind CommentKind, caseId int, objectId int, text string) (*CommentAPIResponse, error) {
+	return client.postComment(fmt.Sprintf("/case/%s/%d/comments/add", kind, objectId), caseId, text)
+}
+
+// UpdateComment replaces the text of a comment through the /case/<kind>/<object-id>/comments/<comment-id>/edit endpoint.
+//
+// Returns:
+// - *CommentAPIResponse*: The response from the API containing the edited comment in the Comment field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateComment(kind CommentKind, caseId int, objectId int, commentId int, text string) (*CommentAPIResponse, error) {
+	return client.postComment(fmt.Sprintf("/case/%s/%d/comments/%d/edit", kind, objectId, commentId), caseId, text)
+}
+
+// DeleteComment removes a comment using the /case/<kind>/<object-id>/comments/<comment-id>/delete endpoint.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) DeleteComment(kind CommentKind, caseId int, objectId int, commentId int) error {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/%s/%d/comments/%d/delete", kind, objectId, commentId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return nil
+}
+
+// postComment sends the text to the add or edit endpoint of a comment, they share request and response
+func (client *APIClient) postComment(url string, caseId int, text string) (*CommentAPIResponse, error) {
+	jsondata, err := json.Marshal(CommentRequest{CommentText: text})
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(url).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var commentResponse CommentAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&commentResponse); err != nil {
+		return nil, err
+	}
+
+	return &commentResponse, nil
+}
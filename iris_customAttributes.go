@@ -0,0 +1,418 @@
+package goiris
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Objects custom attributes can be defined for
+const (
+	AttributesForCase     = "case"
+	AttributesForCustomer = "client"
+	AttributesForAsset    = "asset"
+	AttributesForIoc      = "ioc"
+	AttributesForEvent    = "event"
+	AttributesForTask     = "task"
+	AttributesForNote     = "note"
+	AttributesForEvidence = "evidence"
+)
+
+// Input types of custom attribute fields
+const (
+	AttributeInputString   = "input_string"
+	AttributeInputText     = "input_textfield"
+	AttributeInputCheckbox = "input_checkbox"
+	AttributeInputSelect   = "input_select"
+	AttributeInputDate     = "input_date"
+	AttributeInputDatetime = "input_datetime"
+	AttributeHTML          = "html"
+	AttributeRaw           = "raw"
+)
+
+var attributeInputTypes = []string{
+	AttributeInputString,
+	AttributeInputText,
+	AttributeInputCheckbox,
+	AttributeInputSelect,
+	AttributeInputDate,
+	AttributeInputDatetime,
+	AttributeHTML,
+	AttributeRaw,
+}
+
+// ErrAttributeInvalid is returned when custom attributes do not match their schema
+var ErrAttributeInvalid = errors.New("invalid custom attribute")
+
+// AttributeDefinitionsResponse represents the response of the /manage/attributes/list endpoint
+type AttributeDefinitionsResponse struct {
+	AttributeDefinitions []AttributeDefinition `json:"data"`
+	ApiMeta
+}
+
+// AttributeDefinitionAPIResponse represents the response of a single attribute definition api action
+type AttributeDefinitionAPIResponse struct {
+	AttributeDefinition AttributeDefinition `json:"data"`
+	ApiMeta
+}
+
+// AttributeDefinition represents the custom attributes defined for one object type
+type AttributeDefinition struct {
+	AttributeID          int             `json:"attribute_id"`
+	AttributeDisplayName string          `json:"attribute_display_name"`
+	AttributeDescription string          `json:"attribute_description"`
+	AttributeFor         string          `json:"attribute_for"`
+	AttributeContent     AttributeSchema `json:"attribute_content"`
+}
+
+// AttributeSchema maps tab names to the fields shown on that tab
+type AttributeSchema map[string]map[string]AttributeField
+
+// AttributeField describes a single custom attribute field. In a schema Value is the default value.
+type AttributeField struct {
+	Type      string      `json:"type"`
+	Mandatory bool        `json:"mandatory"`
+	Value     interface{} `json:"value"`
+	Options   []string    `json:"options,omitempty"`
+}
+
+// CustomAttributes holds the custom attribute values of an object as received from IRIS,
+// i.e. tab -> field -> {"type": ..., "mandatory": ..., "value": ...}. Use Values to send them back.
+type CustomAttributes map[string]interface{}
+
+// AttributeValues holds custom attribute values as IRIS expects them on add and update requests,
+// i.e. tab -> field -> value, every tab being a map[string]interface{}. Map literals of that shape can be
+// assigned directly, the CustomAttributes of a response are converted with CustomAttributes.Values.
+// They are sent as they are, use AttributeSchema.Set or AttributeSchema.Validate to check them against
+// the schema first.
+type AttributeValues map[string]interface{}
+
+// UpdateAttributeDefinitionRequest represents a struct for updating the attributes of an object type.
+// PartialOverwrite pushes new fields to existing objects, CompleteOverwrite also resets existing values.
+type UpdateAttributeDefinitionRequest struct {
+	AttributeContent  AttributeSchema
+	PartialOverwrite  bool
+	CompleteOverwrite bool
+}
+
+// Get returns the value of the field on the given tab. Both the {"value": ...} objects IRIS returns and
+// plain values are accepted.
+func (attributes CustomAttributes) Get(tab, field string) (interface{}, bool) {
+	fields, ok := attributes[tab].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	attribute, ok := fields[field]
+	if !ok {
+		return nil, false
+	}
+	if object, ok := attribute.(map[string]interface{}); ok {
+		value, ok := object["value"]
+		return value, ok
+	}
+	return attribute, true
+}
+
+// GetString returns the value of a string, text, select, date or html field
+func (attributes CustomAttributes) GetString(tab, field string) (string, bool) {
+	value, ok := attributes.Get(tab, field)
+	if !ok {
+		return "", false
+	}
+	s, ok := value.(string)
+	return s, ok
+}
+
+// GetBool returns the value of a checkbox field
+func (attributes CustomAttributes) GetBool(tab, field string) (bool, bool) {
+	value, ok := attributes.Get(tab, field)
+	if !ok {
+		return false, false
+	}
+	b, ok := value.(bool)
+	return b, ok
+}
+
+// Values returns the values of all fields in the form add and update requests expect
+func (attributes CustomAttributes) Values() AttributeValues {
+	values := make(AttributeValues)
+	for tab, fields := range attributes {
+		fieldMap, ok := fields.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		tabValues := make(map[string]interface{})
+		for field := range fieldMap {
+			if value, ok := attributes.Get(tab, field); ok {
+				tabValues[field] = value
+			}
+		}
+		values[tab] = tabValues
+	}
+	return values
+}
+
+// Fields returns the values of the given tab, nil if the tab is not set or not a map[string]interface{}
+func (values AttributeValues) Fields(tab string) map[string]interface{} {
+	fields, _ := values[tab].(map[string]interface{})
+	return fields
+}
+
+// Get returns the value of the field on the given tab
+func (values AttributeValues) Get(tab, field string) (interface{}, bool) {
+	value, ok := values.Fields(tab)[field]
+	return value, ok
+}
+
+// Set sets the value of the field on the given tab without validation, see AttributeSchema.Set
+func (values AttributeValues) Set(tab, field string, value interface{}) {
+	fields := values.Fields(tab)
+	if fields == nil {
+		fields = make(map[string]interface{})
+		values[tab] = fields
+	}
+	fields[field] = value
+}
+
+// Field returns the definition of the field on the given tab
+func (schema AttributeSchema) Field(tab, field string) (AttributeField, bool) {
+	definition, ok := schema[tab][field]
+	return definition, ok
+}
+
+// Set validates value against the field definition and stores it in values. values may be nil,
+// the map to send is returned.
+//
+// Example usage:
+//
+//	schema, _ := client.GetAttributeSchema(goiris.AttributesForCustomer)
+//	values, err := schema.Set(customer.CustomAttributes.Values(), "Contract", "Tier", "gold")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (schema AttributeSchema) Set(values AttributeValues, tab, field string, value interface{}) (AttributeValues, error) {
+	definition, ok := schema.Field(tab, field)
+	if !ok {
+		return values, fmt.Errorf("%w: unknown field %s/%s", ErrAttributeInvalid, tab, field)
+	}
+	if err := definition.Validate(value); err != nil {
+		return values, fmt.Errorf("%s/%s: %w", tab, field, err)
+	}
+
+	if values == nil {
+		values = make(AttributeValues)
+	}
+	values.Set(tab, field, value)
+	return values, nil
+}
+
+// Validate checks every value against the schema and that all mandatory fields are set.
+// All problems are returned at once.
+func (schema AttributeSchema) Validate(values AttributeValues) error {
+	var errs []error
+
+	for tab, fields := range schema {
+		for field, definition := range fields {
+			value, ok := values.Get(tab, field)
+			if !ok || value == nil || value == "" {
+				if definition.Mandatory {
+					errs = append(errs, fmt.Errorf("%w: %s/%s is mandatory", ErrAttributeInvalid, tab, field))
+				}
+				continue
+			}
+			if err := definition.Validate(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s/%s: %w", tab, field, err))
+			}
+		}
+	}
+
+	for tab := range values {
+		fields := values.Fields(tab)
+		if fields == nil {
+			errs = append(errs, fmt.Errorf("%w: tab %s is not a map of fields", ErrAttributeInvalid, tab))
+			continue
+		}
+		for field := range fields {
+			if _, ok := schema.Field(tab, field); !ok {
+				errs = append(errs, fmt.Errorf("%w: unknown field %s/%s", ErrAttributeInvalid, tab, field))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate checks that value can be stored in a field of this type
+func (definition AttributeField) Validate(value interface{}) error {
+	switch definition.Type {
+	case AttributeInputCheckbox:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%w: %s expects a bool, got %T", ErrAttributeInvalid, definition.Type, value)
+		}
+	case AttributeInputString, AttributeInputText, AttributeHTML:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%w: %s expects a string, got %T", ErrAttributeInvalid, definition.Type, value)
+		}
+	case AttributeInputSelect:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%w: %s expects a string, got %T", ErrAttributeInvalid, definition.Type, value)
+		}
+		if !slices.Contains(definition.Options, s) {
+			return fmt.Errorf("%w: %q is not one of %s", ErrAttributeInvalid, s, strings.Join(definition.Options, ", "))
+		}
+	case AttributeInputDate:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%w: %s expects a string, got %T", ErrAttributeInvalid, definition.Type, value)
+		}
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return fmt.Errorf("%w: %q is not a date (YYYY-MM-DD)", ErrAttributeInvalid, s)
+		}
+	case AttributeInputDatetime:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%w: %s expects a string, got %T", ErrAttributeInvalid, definition.Type, value)
+		}
+		if _, err := time.Parse("2006-01-02T15:04", s); err != nil {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				return fmt.Errorf("%w: %q is not a datetime", ErrAttributeInvalid, s)
+			}
+		}
+	case AttributeRaw:
+	default:
+		return fmt.Errorf("%w: unknown input type %q", ErrAttributeInvalid, definition.Type)
+	}
+
+	return nil
+}
+
+// ListAttributeDefinitions gets the custom attribute definitions of all object types from the /manage/attributes/list endpoint.
+//
+// Returns:
+// - *AttributeDefinitionsResponse*: The response from the API containing the definitions in the AttributeDefinitions field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListAttributeDefinitions() (*AttributeDefinitionsResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/attributes/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var definitionsResponse AttributeDefinitionsResponse
+	if err := json.NewDecoder(req.Body).Decode(&definitionsResponse); err != nil {
+		return nil, err
+	}
+
+	return &definitionsResponse, nil
+}
+
+// GetAttributeSchema returns the custom attribute schema of an object type, e.g. AttributesForCustomer.
+//
+// Returns:
+// - AttributeSchema: The tabs and fields defined for the object type.
+// - error: An error if the request fails or no definition exists for the object type.
+func (client *APIClient) GetAttributeSchema(objectType string) (AttributeSchema, error) {
+	definitions, err := client.ListAttributeDefinitions()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, definition := range definitions.AttributeDefinitions {
+		if definition.AttributeFor == objectType {
+			if definition.AttributeContent == nil {
+				return AttributeSchema{}, nil
+			}
+			return definition.AttributeContent, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no attribute definition for %q", objectType)
+}
+
+// ValidateCustomAttributes fetches the schema of objectType and validates values against it
+// before they are sent along with an add or update request.
+//
+// Returns:
+// - error: ErrAttributeInvalid wrapped for every problem, or an error if the schema cannot be fetched.
+func (client *APIClient) ValidateCustomAttributes(objectType string, values AttributeValues) error {
+	schema, err := client.GetAttributeSchema(objectType)
+	if err != nil {
+		return err
+	}
+
+	return schema.Validate(values)
+}
+
+// UpdateAttributeDefinition replaces the custom attributes of an object type through the /manage/attributes/update/<id> endpoint.
+//
+// Returns:
+// - *AttributeDefinitionAPIResponse*: The response from the API containing the updated definition.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateAttributeDefinition(attributeId int, definition UpdateAttributeDefinitionRequest) (*AttributeDefinitionAPIResponse, error) {
+	for tab, fields := range definition.AttributeContent {
+		for field, attribute := range fields {
+			if !slices.Contains(attributeInputTypes, attribute.Type) {
+				return nil, fmt.Errorf("%s/%s: %w: unknown input type %q", tab, field, ErrAttributeInvalid, attribute.Type)
+			}
+			// the default value may be left empty
+			if attribute.Value == nil || attribute.Value == "" {
+				continue
+			}
+			if err := attribute.Validate(attribute.Value); err != nil {
+				return nil, fmt.Errorf("%s/%s: %w", tab, field, err)
+			}
+		}
+	}
+
+	content, err := json.Marshal(definition.AttributeContent)
+	if err != nil {
+		return nil, err
+	}
+
+	jsondata, err := json.Marshal(map[string]interface{}{
+		"attribute_content":  string(content),
+		"partial_overwrite":  definition.PartialOverwrite,
+		"complete_overwrite": definition.CompleteOverwrite,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/attributes/update/%d", attributeId)).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var definitionResponse AttributeDefinitionAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&definitionResponse); err != nil {
+		return nil, err
+	}
+
+	return &definitionResponse, nil
+}
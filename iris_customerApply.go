@@ -0,0 +1,491 @@
+package goiris
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Actions of a CustomerChange
+const (
+	PlanCreate = "create"
+	PlanUpdate = "update"
+	PlanDelete = "delete"
+)
+
+// CustomerState is the desired state of the customer roster, usually loaded with LoadCustomerState.
+//
+//	customers:
+//	  - name: ACME
+//	    description: ACME Corp.
+//	    sla: 4h response
+//	    custom_attributes:
+//	      Contract:
+//	        Tier: gold
+//	    contacts:
+//	      - name: Jane Doe
+//	        role: CISO
+//	        email: jane@acme.example
+type CustomerState struct {
+	Customers []DesiredCustomer `json:"customers" yaml:"customers"`
+}
+
+// DesiredCustomer is a customer and its contacts as they should exist on the instance.
+// CustomAttributes map tab -> field -> value, fields not listed are left untouched.
+type DesiredCustomer struct {
+	Name             string                            `json:"name" yaml:"name"`
+	Description      string                            `json:"description" yaml:"description"`
+	SLA              string                            `json:"sla" yaml:"sla"`
+	CustomAttributes map[string]map[string]interface{} `json:"custom_attributes" yaml:"custom_attributes"`
+	Contacts         []DesiredContact                  `json:"contacts" yaml:"contacts"`
+}
+
+// DesiredContact is a contact as it should exist on the instance, contacts are matched by name
+type DesiredContact struct {
+	Name             string                            `json:"name" yaml:"name"`
+	Role             string                            `json:"role" yaml:"role"`
+	Email            string                            `json:"email" yaml:"email"`
+	MobilePhone      string                            `json:"mobile_phone" yaml:"mobile_phone"`
+	WorkPhone        string                            `json:"work_phone" yaml:"work_phone"`
+	Note             string                            `json:"note" yaml:"note"`
+	CustomAttributes map[string]map[string]interface{} `json:"custom_attributes" yaml:"custom_attributes"`
+}
+
+// CustomerPlanOptions controls what PlanCustomers is allowed to do
+type CustomerPlanOptions struct {
+	// PruneContacts deletes contacts of managed customers that are not part of the state.
+	// Customers themselves are never deleted.
+	PruneContacts bool
+}
+
+// CustomerChange is a single create, update or delete planned by PlanCustomers
+type CustomerChange struct {
+	Action       string
+	CustomerName string
+	ContactName  string
+	CustomerID   int
+	ContactID    int
+	Diff         []string
+
+	customer *UpdateCustomerRequest
+	contact  *UpdateContactRequest
+}
+
+// IsContact reports whether the change is about a contact rather than a customer
+func (change CustomerChange) IsContact() bool {
+	return change.ContactName != ""
+}
+
+// CustomerPlan is the ordered list of changes needed to reach a CustomerState
+type CustomerPlan struct {
+	Changes []CustomerChange
+}
+
+// Empty reports whether the instance already matches the state
+func (plan *CustomerPlan) Empty() bool {
+	return len(plan.Changes) == 0
+}
+
+// String renders the plan as a diff: + create, ~ update, - delete
+func (plan *CustomerPlan) String() string {
+	if plan.Empty() {
+		return "no changes\n"
+	}
+
+	var sb strings.Builder
+	for _, change := range plan.Changes {
+		symbol := map[string]string{PlanCreate: "+", PlanUpdate: "~", PlanDelete: "-"}[change.Action]
+		if change.IsContact() {
+			fmt.Fprintf(&sb, "%s contact %q of customer %q\n", symbol, change.ContactName, change.CustomerName)
+		} else {
+			fmt.Fprintf(&sb, "%s customer %q\n", symbol, change.CustomerName)
+		}
+		for _, line := range change.Diff {
+			fmt.Fprintf(&sb, "    %s\n", line)
+		}
+	}
+	return sb.String()
+}
+
+// LoadCustomerState reads a desired state from a .json, .yaml or .yml file
+func LoadCustomerState(path string) (*CustomerState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var state CustomerState
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &state)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &state)
+	default:
+		err = fmt.Errorf("unsupported state file extension: %s", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, state.Validate()
+}
+
+// Validate checks that every customer and contact has a name and names are unique.
+// IRIS compares customer names case-insensitively, so does Validate.
+func (state *CustomerState) Validate() error {
+	var errs []error
+
+	customers := make(map[string]bool)
+	for i, customer := range state.Customers {
+		key := strings.ToLower(strings.TrimSpace(customer.Name))
+		if key == "" {
+			errs = append(errs, fmt.Errorf("customers[%d]: name is required", i))
+			continue
+		}
+		if customers[key] {
+			errs = append(errs, fmt.Errorf("customers[%d]: duplicate customer %q", i, customer.Name))
+		}
+		customers[key] = true
+
+		contacts := make(map[string]bool)
+		for j, contact := range customer.Contacts {
+			contactKey := strings.ToLower(strings.TrimSpace(contact.Name))
+			if contactKey == "" {
+				errs = append(errs, fmt.Errorf("customers[%d].contacts[%d]: name is required", i, j))
+				continue
+			}
+			if contacts[contactKey] {
+				errs = append(errs, fmt.Errorf("customers[%d].contacts[%d]: duplicate contact %q", i, j, contact.Name))
+			}
+			contacts[contactKey] = true
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// PlanCustomers compares state with the customers and contacts on the instance and returns the
+// changes needed to reach it. Nothing is modified, print the plan for a dry run and pass it to
+// ApplyCustomerPlan to execute it.
+//
+// Example usage:
+//
+//	state, err := goiris.LoadCustomerState("customers.yaml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	plan, err := client.PlanCustomers(*state, goiris.CustomerPlanOptions{PruneContacts: true})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Print(plan)
+//	if !dryRun {
+//		err = client.ApplyCustomerPlan(plan)
+//	}
+//
+// Returns:
+// - *CustomerPlan*: The changes in the order they have to be applied.
+// - error: An error if the state is invalid or a request fails.
+func (client *APIClient) PlanCustomers(state CustomerState, opts CustomerPlanOptions) (*CustomerPlan, error) {
+	if err := state.Validate(); err != nil {
+		return nil, err
+	}
+
+	customers, err := client.GetCustomers()
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]Customer)
+	for _, customer := range customers.Customers {
+		existing[strings.ToLower(strings.TrimSpace(customer.CustomerName))] = customer
+	}
+
+	plan := &CustomerPlan{}
+	for _, desired := range state.Customers {
+		listed, ok := existing[strings.ToLower(strings.TrimSpace(desired.Name))]
+		if !ok {
+			request := UpdateCustomerRequest{
+				CustomerName:        desired.Name,
+				CustomerDescription: desired.Description,
+				CustomerSLA:         desired.SLA,
+				CustomAttributes:    mergeCustomAttributes(nil, specAttributeValues(desired.CustomAttributes)),
+			}
+			plan.Changes = append(plan.Changes, CustomerChange{
+				Action:       PlanCreate,
+				CustomerName: desired.Name,
+				Diff:         diffCustomer(UpdateCustomerRequest{}, request),
+				customer:     &request,
+			})
+			for _, contact := range desired.Contacts {
+				contactRequest := desiredContactRequest(UpdateContactRequest{}, contact)
+				plan.Changes = append(plan.Changes, CustomerChange{
+					Action:       PlanCreate,
+					CustomerName: desired.Name,
+					ContactName:  contact.Name,
+					Diff:         diffContact(UpdateContactRequest{}, contactRequest),
+					contact:      &contactRequest,
+				})
+			}
+			continue
+		}
+
+		// the list endpoint does not return contacts
+		current, err := client.GetCustomer(listed.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+
+		currentRequest := UpdateCustomerRequest{
+			CustomerName:        current.CustomerName,
+			CustomerDescription: current.CustomerDescription,
+			CustomerSLA:         current.CustomerSLA,
+			CustomAttributes:    current.CustomAttributes.Values(),
+		}
+		request := UpdateCustomerRequest{
+			CustomerName:        desired.Name,
+			CustomerDescription: desired.Description,
+			CustomerSLA:         desired.SLA,
+			CustomAttributes:    mergeCustomAttributes(current.CustomAttributes.Values(), specAttributeValues(desired.CustomAttributes)),
+		}
+		if diff := diffCustomer(currentRequest, request); len(diff) > 0 {
+			plan.Changes = append(plan.Changes, CustomerChange{
+				Action:       PlanUpdate,
+				CustomerName: desired.Name,
+				CustomerID:   current.CustomerID,
+				Diff:         diff,
+				customer:     &request,
+			})
+		}
+
+		contacts := make(map[string]Contact)
+		for _, contact := range current.Contacts {
+			contacts[strings.ToLower(strings.TrimSpace(contact.ContactName))] = contact
+		}
+
+		for _, contact := range desired.Contacts {
+			key := strings.ToLower(strings.TrimSpace(contact.Name))
+			currentContact, ok := contacts[key]
+			if !ok {
+				contactRequest := desiredContactRequest(UpdateContactRequest{}, contact)
+				plan.Changes = append(plan.Changes, CustomerChange{
+					Action:       PlanCreate,
+					CustomerName: desired.Name,
+					ContactName:  contact.Name,
+					CustomerID:   current.CustomerID,
+					Diff:         diffContact(UpdateContactRequest{}, contactRequest),
+					contact:      &contactRequest,
+				})
+				continue
+			}
+			delete(contacts, key)
+
+			currentContactRequest := UpdateContactRequest{
+				ContactName:        currentContact.ContactName,
+				ContactRole:        currentContact.ContactRole,
+				ContactEmail:       currentContact.ContactEmail,
+				ContactMobilePhone: currentContact.ContactMobilePhone,
+				ContactWorkPhone:   currentContact.ContactWorkPhone,
+				ContactNote:        currentContact.ContactNote,
+				CustomAttributes:   currentContact.CustomAttributes.Values(),
+			}
+			contactRequest := desiredContactRequest(currentContactRequest, contact)
+			if diff := diffContact(currentContactRequest, contactRequest); len(diff) > 0 {
+				plan.Changes = append(plan.Changes, CustomerChange{
+					Action:       PlanUpdate,
+					CustomerName: desired.Name,
+					ContactName:  contact.Name,
+					CustomerID:   current.CustomerID,
+					ContactID:    currentContact.ID,
+					Diff:         diff,
+					contact:      &contactRequest,
+				})
+			}
+		}
+
+		if opts.PruneContacts {
+			var stale []Contact
+			for _, contact := range contacts {
+				stale = append(stale, contact)
+			}
+			sort.Slice(stale, func(i, j int) bool { return stale[i].ID < stale[j].ID })
+			for _, contact := range stale {
+				plan.Changes = append(plan.Changes, CustomerChange{
+					Action:       PlanDelete,
+					CustomerName: desired.Name,
+					ContactName:  contact.ContactName,
+					CustomerID:   current.CustomerID,
+					ContactID:    contact.ID,
+				})
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// ApplyCustomerPlan executes the changes of plan in order. It stops at the first failing change,
+// changes applied before stay applied; planning again picks up where it stopped.
+//
+// Returns:
+// - error: An error naming the change that failed.
+func (client *APIClient) ApplyCustomerPlan(plan *CustomerPlan) error {
+	created := make(map[string]int)
+
+	for _, change := range plan.Changes {
+		customerId := change.CustomerID
+		if customerId == 0 {
+			customerId = created[change.CustomerName]
+		}
+
+		var err error
+		switch {
+		case !change.IsContact() && change.Action == PlanCreate:
+			var customer *CustomerAddResponse
+			customer, err = client.AddCustomer(AddCustomerRequest(*change.customer))
+			if err == nil {
+				created[change.CustomerName] = customer.Customer.CustomerID
+			}
+		case !change.IsContact() && change.Action == PlanUpdate:
+			_, err = client.UpdateCustomer(customerId, *change.customer)
+		case change.Action == PlanCreate:
+			contact := change.contact
+			_, err = client.AddCustomerContact(customerId, AddCustomerContactRequest{
+				ContactName:        contact.ContactName,
+				ContactRole:        contact.ContactRole,
+				ContactEmail:       contact.ContactEmail,
+				ContactMobilePhone: contact.ContactMobilePhone,
+				ContactPhone:       contact.ContactWorkPhone,
+				ContactNote:        contact.ContactNote,
+				CustomAttributes:   contact.CustomAttributes,
+			})
+		case change.Action == PlanUpdate:
+			_, err = client.UpdateCustomerContact(customerId, change.ContactID, *change.contact)
+		case change.Action == PlanDelete:
+			err = client.DeleteCustomerContact(customerId, change.ContactID)
+		}
+
+		if err != nil {
+			if change.IsContact() {
+				return fmt.Errorf("%s contact %q of customer %q: %w", change.Action, change.ContactName, change.CustomerName, err)
+			}
+			return fmt.Errorf("%s customer %q: %w", change.Action, change.CustomerName, err)
+		}
+	}
+
+	return nil
+}
+
+func desiredContactRequest(current UpdateContactRequest, desired DesiredContact) UpdateContactRequest {
+	return UpdateContactRequest{
+		ContactName:        desired.Name,
+		ContactRole:        desired.Role,
+		ContactEmail:       desired.Email,
+		ContactMobilePhone: desired.MobilePhone,
+		ContactWorkPhone:   desired.WorkPhone,
+		ContactNote:        desired.Note,
+		CustomAttributes:   mergeCustomAttributes(current.CustomAttributes, specAttributeValues(desired.CustomAttributes)),
+	}
+}
+
+// mergeCustomAttributes returns the values of current with the values of desired set
+func mergeCustomAttributes(current, desired AttributeValues) AttributeValues {
+	merged := make(AttributeValues)
+	for _, values := range []AttributeValues{current, desired} {
+		for tab := range values {
+			for field, value := range values.Fields(tab) {
+				merged.Set(tab, field, value)
+			}
+		}
+	}
+	return merged
+}
+
+// specAttributeValues converts the custom attributes of a spec to request values
+func specAttributeValues(attributes map[string]map[string]interface{}) AttributeValues {
+	values := make(AttributeValues, len(attributes))
+	for tab, fields := range attributes {
+		values[tab] = fields
+	}
+	return values
+}
+
+func diffCustomer(current, desired UpdateCustomerRequest) []string {
+	var diff []string
+	diffField := func(field, a, b string) {
+		if a != b {
+			diff = append(diff, fmt.Sprintf("%s: %q -> %q", field, a, b))
+		}
+	}
+
+	diffField("name", current.CustomerName, desired.CustomerName)
+	diffField("description", current.CustomerDescription, desired.CustomerDescription)
+	diffField("sla", current.CustomerSLA, desired.CustomerSLA)
+	diff = append(diff, diffCustomAttributes(current.CustomAttributes, desired.CustomAttributes)...)
+	return diff
+}
+
+func diffContact(current, desired UpdateContactRequest) []string {
+	var diff []string
+	diffField := func(field, a, b string) {
+		if a != b {
+			diff = append(diff, fmt.Sprintf("%s: %q -> %q", field, a, b))
+		}
+	}
+
+	diffField("name", current.ContactName, desired.ContactName)
+	diffField("role", current.ContactRole, desired.ContactRole)
+	diffField("email", current.ContactEmail, desired.ContactEmail)
+	diffField("mobile_phone", current.ContactMobilePhone, desired.ContactMobilePhone)
+	diffField("work_phone", current.ContactWorkPhone, desired.ContactWorkPhone)
+	diffField("note", current.ContactNote, desired.ContactNote)
+	diff = append(diff, diffCustomAttributes(current.CustomAttributes, desired.CustomAttributes)...)
+	return diff
+}
+
+func diffCustomAttributes(current, desired AttributeValues) []string {
+	var diff []string
+
+	tabs := make([]string, 0, len(desired))
+	for tab := range desired {
+		tabs = append(tabs, tab)
+	}
+	sort.Strings(tabs)
+
+	for _, tab := range tabs {
+		fields := make([]string, 0, len(desired.Fields(tab)))
+		for field := range desired.Fields(tab) {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			desiredValue, _ := desired.Get(tab, field)
+			currentValue, _ := current.Get(tab, field)
+			if !reflect.DeepEqual(normalizeAttributeValue(currentValue), normalizeAttributeValue(desiredValue)) {
+				diff = append(diff, fmt.Sprintf("custom_attributes.%s.%s: %v -> %v", tab, field, currentValue, desiredValue))
+			}
+		}
+	}
+	return diff
+}
+
+// normalizeAttributeValue makes values decoded from YAML comparable to values decoded from JSON
+func normalizeAttributeValue(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
@@ -0,0 +1,204 @@
+package goiris
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCustomerNotFound is returned when no customer matches the given name or UUID
+var ErrCustomerNotFound = errors.New("customer not found")
+
+// ErrContactNotFound is returned when a customer has no contact with the given email
+var ErrContactNotFound = errors.New("contact not found")
+
+// FindCustomerByName returns the customer with the given name. Names are compared
+// case-insensitively and ignoring surrounding whitespace, the same way IRIS checks for duplicates.
+//
+// Example usage:
+//
+//	customer, err := client.FindCustomerByName("acme")
+//	if errors.Is(err, goiris.ErrCustomerNotFound) {
+//	    ...
+//	}
+//
+// Returns:
+// - *Customer*: The customer as returned by the list endpoint, without contacts.
+// - error: ErrCustomerNotFound or an error if the request fails.
+func (client *APIClient) FindCustomerByName(name string) (*Customer, error) {
+	customers, err := client.GetCustomers()
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.ToLower(strings.TrimSpace(name))
+	for _, customer := range customers.Customers {
+		if strings.ToLower(strings.TrimSpace(customer.CustomerName)) == key {
+			return &customer, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: name %q", ErrCustomerNotFound, name)
+}
+
+// FindCustomerByUUID returns the customer with the given UUID.
+//
+// Returns:
+// - *Customer*: The customer as returned by the list endpoint, without contacts.
+// - error: ErrCustomerNotFound or an error if the request fails.
+func (client *APIClient) FindCustomerByUUID(uuid string) (*Customer, error) {
+	customers, err := client.GetCustomers()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, customer := range customers.Customers {
+		if strings.EqualFold(customer.CustomerUUID, uuid) {
+			return &customer, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: uuid %q", ErrCustomerNotFound, uuid)
+}
+
+// FindContact returns the contact of a customer with the given email, compared case-insensitively.
+//
+// Returns:
+// - *Contact*: The matching contact.
+// - error: ErrContactNotFound or an error if the request fails.
+func (client *APIClient) FindContact(customerId int, email string) (*Contact, error) {
+	customer, err := client.GetCustomer(customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, contact := range customer.Contacts {
+		if strings.EqualFold(strings.TrimSpace(contact.ContactEmail), strings.TrimSpace(email)) {
+			return &contact, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: customer %d has no contact %q", ErrContactNotFound, customerId, email)
+}
+
+// UpsertCustomer creates the customer or updates the existing one with the same name.
+// The name is matched case-insensitively, so "ACME" updates an existing "acme" (and renames it)
+// instead of running into the duplicate name IRIS rejects. Nothing is sent if the customer is already
+// up to date, only the custom attributes given in customer are changed.
+//
+// Example usage:
+//
+//	customerId, created, err := client.UpsertCustomer(goiris.AddCustomerRequest{
+//		CustomerName: "ACME",
+//		CustomerSLA:  "4h",
+//	})
+//
+// Returns:
+// - int: The ID of the created or updated customer.
+// - bool: Whether the customer was created.
+// - error: An error if a request fails.
+func (client *APIClient) UpsertCustomer(customer AddCustomerRequest) (int, bool, error) {
+	existing, err := client.FindCustomerByName(customer.CustomerName)
+	if errors.Is(err, ErrCustomerNotFound) {
+		if customer.CustomAttributes == nil {
+			customer.CustomAttributes = AttributeValues{}
+		}
+		created, err := client.AddCustomer(customer)
+		if err != nil {
+			return 0, false, err
+		}
+		return created.Customer.CustomerID, true, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	current, err := client.GetCustomer(existing.CustomerID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	currentRequest := UpdateCustomerRequest{
+		CustomerName:        current.CustomerName,
+		CustomerDescription: current.CustomerDescription,
+		CustomerSLA:         current.CustomerSLA,
+		CustomAttributes:    current.CustomAttributes.Values(),
+	}
+	request := UpdateCustomerRequest{
+		CustomerName:        customer.CustomerName,
+		CustomerDescription: customer.CustomerDescription,
+		CustomerSLA:         customer.CustomerSLA,
+		CustomAttributes:    mergeCustomAttributes(current.CustomAttributes.Values(), customer.CustomAttributes),
+	}
+	if len(diffCustomer(currentRequest, request)) == 0 {
+		return current.CustomerID, false, nil
+	}
+
+	if _, err := client.UpdateCustomer(current.CustomerID, request); err != nil {
+		return 0, false, err
+	}
+	return current.CustomerID, false, nil
+}
+
+// UpsertContact creates the contact or updates the existing contact of the customer with the same email.
+// Contacts without email are matched by name. Both are compared case-insensitively.
+// Nothing is sent if the contact is already up to date.
+//
+// Returns:
+// - int: The ID of the created or updated contact.
+// - bool: Whether the contact was created.
+// - error: An error if a request fails.
+func (client *APIClient) UpsertContact(customerId int, contact AddCustomerContactRequest) (int, bool, error) {
+	customer, err := client.GetCustomer(customerId)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var existing *Contact
+	for i, current := range customer.Contacts {
+		sameEmail := contact.ContactEmail != "" && strings.EqualFold(strings.TrimSpace(current.ContactEmail), strings.TrimSpace(contact.ContactEmail))
+		sameName := contact.ContactEmail == "" && strings.EqualFold(strings.TrimSpace(current.ContactName), strings.TrimSpace(contact.ContactName))
+		if sameEmail || sameName {
+			existing = &customer.Contacts[i]
+			break
+		}
+	}
+
+	if existing == nil {
+		if contact.CustomAttributes == nil {
+			contact.CustomAttributes = AttributeValues{}
+		}
+		created, err := client.AddCustomerContact(customerId, contact)
+		if err != nil {
+			return 0, false, err
+		}
+		return created.Contact.ID, true, nil
+	}
+
+	currentRequest := UpdateContactRequest{
+		ContactName:        existing.ContactName,
+		ContactRole:        existing.ContactRole,
+		ContactEmail:       existing.ContactEmail,
+		ContactMobilePhone: existing.ContactMobilePhone,
+		ContactWorkPhone:   existing.ContactWorkPhone,
+		ContactNote:        existing.ContactNote,
+		CustomAttributes:   existing.CustomAttributes.Values(),
+	}
+	request := UpdateContactRequest{
+		ContactName:        contact.ContactName,
+		ContactRole:        contact.ContactRole,
+		ContactEmail:       contact.ContactEmail,
+		ContactMobilePhone: contact.ContactMobilePhone,
+		ContactWorkPhone:   contact.ContactPhone,
+		ContactNote:        contact.ContactNote,
+		CustomAttributes:   mergeCustomAttributes(existing.CustomAttributes.Values(), contact.CustomAttributes),
+	}
+	if len(diffContact(currentRequest, request)) == 0 {
+		return existing.ID, false, nil
+	}
+
+	if _, err := client.UpdateCustomerContact(customerId, existing.ID, request); err != nil {
+		return 0, false, err
+	}
+	return existing.ID, false, nil
+}
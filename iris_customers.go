@@ -38,7 +38,7 @@ type Customer struct {
 	CustomerName        string `json:"customer_name"`
 	CustomerSLA         string `json:"customer_sla"`
 	CustomerUUID        string `json:"customer_uuid"`
-	CustomAttributes    map[string]interface{} `json:"custom_attributes"`
+	CustomAttributes    CustomAttributes `json:"custom_attributes"`
 }
 
 // UpdateCustomerRequest represents a struct for updating an existing customer
@@ -46,7 +46,7 @@ type UpdateCustomerRequest struct {
 	CustomerName        string            `json:"customer_name"`
 	CustomerDescription string            `json:"customer_description"`
 	CustomerSLA         string            `json:"customer_sla"`
-	CustomAttributes    map[string]interface{} `json:"custom_attributes"`
+	CustomAttributes    AttributeValues `json:"custom_attributes"`
 }
 
 // AddCustomerRequest represents a struct for adding a new customer
@@ -54,14 +54,14 @@ type AddCustomerRequest struct {
 	CustomerName        string      `json:"customer_name"`
 	CustomerDescription string      `json:"customer_description"`
 	CustomerSLA         string      `json:"customer_sla"`
-	CustomAttributes    map[string]interface{} `json:"custom_attributes"`
+	CustomAttributes    AttributeValues `json:"custom_attributes"`
 }
 
 // CustomerAddResponse represents a single Customer object after creation
 type CustomerAddResponseObject struct {
 	ClientUUID          string `json:"client_uuid"`
 	CreationDate        string `json:"creation_date"`
-	CustomAttributes    map[string]interface{}    `json:"custom_attributes"`
+	CustomAttributes    CustomAttributes    `json:"custom_attributes"`
 	CustomerDescription string `json:"customer_description"`
 	CustomerID          int    `json:"customer_id"`
 	CustomerName        string `json:"customer_name"`
@@ -79,7 +79,7 @@ type Contact struct {
 	ContactRole        string `json:"contact_role"`
 	ContactUUID        string `json:"contact_uuid"`
 	ContactWorkPhone   string `json:"contact_work_phone"`
-	CustomAttributes   map[string]interface{} `json:"custom_attributes"`
+	CustomAttributes   CustomAttributes `json:"custom_attributes"`
 	ID                 int    `json:"id"`
 }
 
@@ -91,7 +91,7 @@ type AddCustomerContactRequest struct {
 	ContactMobilePhone string `json:"contact_mobile_phone"`
 	ContactPhone			 string `json:"contact_work_phone"`
 	ContactNote        string `json:"contact_note"`
-	CustomAttributes   map[string]interface{} `json:"custom_attributes"`
+	CustomAttributes   AttributeValues `json:"custom_attributes"`
 }
 
 // UpdateContactRequest represents a struct for updating an existing contact
@@ -102,7 +102,7 @@ type UpdateContactRequest struct {
 	ContactMobilePhone string `json:"contact_mobile_phone"`
 	ContactWorkPhone   string `json:"contact_work_phone"`
 	ContactNote        string `json:"contact_note"`
-	CustomAttributes   map[string]interface{} `json:"custom_attributes"`
+	CustomAttributes   AttributeValues `json:"custom_attributes"`
 }
 
 
@@ -423,11 +423,20 @@ func (client *APIClient) UpdateCustomerContact(customerId int, contactId int, co
 		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
 	}
 
-	var customerContactResponse CustomerContactAddResponse
-	if err := json.NewDecoder(req.Body).Decode(&customerContactResponse); err != nil {
+	type CustomerContactResponseWrapper struct {
+		Contact Contact `json:"data"`
+		ApiMeta
+	}
+
+	var customerContactResponseWrapper CustomerContactResponseWrapper
+	if err := json.NewDecoder(req.Body).Decode(&customerContactResponseWrapper); err != nil {
 		return nil, err
 	}
 
+	customerContactResponse := CustomerContactAddResponse{
+		ApiMeta: customerContactResponseWrapper.ApiMeta,
+		Contact: customerContactResponseWrapper.Contact,
+	}
 	return &customerContactResponse, nil
 }
 
@@ -445,7 +454,7 @@ func (client *APIClient) DeleteCustomerContact(customerId int, contactId int) er
 	defer req.Body.Close()
 
 	if req.StatusCode != http.StatusOK {
-		return err
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
 	}
 
 	return nil
@@ -0,0 +1,277 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// UserCasesResponse represents the response of the /user/cases/list endpoint
+type UserCasesResponse struct {
+	Cases []UserCase `json:"data"`
+	ApiMeta
+}
+
+// UserCase represents a case owned by the current user on the dashboard
+type UserCase struct {
+	CaseID      int    `json:"case_id"`
+	CaseUUID    string `json:"case_uuid"`
+	Name        string `json:"name"`
+	SocID       string `json:"soc_id"`
+	Description string `json:"description"`
+	OpenDate    string `json:"open_date"`
+	CloseDate   string `json:"close_date"`
+	Client      struct {
+		CustomerID   int    `json:"customer_id"`
+		CustomerName string `json:"customer_name"`
+	} `json:"client"`
+	Owner struct {
+		ID       int    `json:"id"`
+		UserName string `json:"user_name"`
+	} `json:"owner"`
+	State struct {
+		StateID   int    `json:"state_id"`
+		StateName string `json:"state_name"`
+	} `json:"state"`
+}
+
+// UserTasksResponse represents the response of the /user/tasks/list endpoint
+type UserTasksResponse struct {
+	Data struct {
+		Tasks        []UserTask   `json:"tasks"`
+		TaskStatuses []TaskStatus `json:"tasks_status"`
+	} `json:"data"`
+	ApiMeta
+}
+
+// UserTask represents a case task assigned to the current user
+type UserTask struct {
+	TaskID          int    `json:"task_id"`
+	TaskTitle       string `json:"task_title"`
+	TaskDescription string `json:"task_description"`
+	TaskTags        string `json:"task_tags"`
+	TaskStatusID    int    `json:"task_status_id"`
+	StatusName      string `json:"status_name"`
+	StatusBsColor   string `json:"status_bscolor"`
+	TaskCaseID      int    `json:"task_case_id"`
+	TaskCase        string `json:"task_case"`
+	TaskLastUpdate  string `json:"task_last_update"`
+}
+
+// CaseChartsResponse represents the response of the /dashboard/case_charts endpoint
+type CaseChartsResponse struct {
+	Charts CaseCharts `json:"data"`
+	ApiMeta
+}
+
+// CaseCharts holds the case statistics of the dashboard
+type CaseCharts struct {
+	ClosedCases ChartSeries `json:"closed_cases"`
+}
+
+// ChartSeries is a series of counts with their labels, usually dates
+type ChartSeries struct {
+	Labels []string `json:"labels"`
+	Data   []int    `json:"data"`
+}
+
+// Total returns the sum of the series
+func (series ChartSeries) Total() int {
+	total := 0
+	for _, count := range series.Data {
+		total += count
+	}
+	return total
+}
+
+// ListUserCases gets the cases of the current user from the /user/cases/list endpoint.
+//
+// Returns:
+// - *UserCasesResponse*: The response from the API containing the cases in the Cases field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListUserCases(showClosed bool) (*UserCasesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/user/cases/list").
+		SetMethod(http.MethodGet).
+		AddQueryParam("show_closed", strconv.FormatBool(showClosed)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var userCasesResponse UserCasesResponse
+	if err := json.NewDecoder(req.Body).Decode(&userCasesResponse); err != nil {
+		return nil, err
+	}
+
+	return &userCasesResponse, nil
+}
+
+// ListUserTasks gets the case tasks assigned to the current user from the /user/tasks/list endpoint.
+//
+// Returns:
+// - *UserTasksResponse*: The response from the API containing the tasks in the Data.Tasks field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListUserTasks() (*UserTasksResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/user/tasks/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var userTasksResponse UserTasksResponse
+	if err := json.NewDecoder(req.Body).Decode(&userTasksResponse); err != nil {
+		return nil, err
+	}
+
+	return &userTasksResponse, nil
+}
+
+// GetCaseCharts gets the case statistics from the /dashboard/case_charts endpoint.
+//
+// Returns:
+// - *CaseChartsResponse*: The response from the API containing the statistics in the Charts field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetCaseCharts() (*CaseChartsResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/dashboard/case_charts").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var caseChartsResponse CaseChartsResponse
+	if err := json.NewDecoder(req.Body).Decode(&caseChartsResponse); err != nil {
+		return nil, err
+	}
+
+	return &caseChartsResponse, nil
+}
+
+// Dashboard is everything the IRIS dashboard shows to the current user
+type Dashboard struct {
+	Cases       []UserCase
+	Tasks       []UserTask
+	GlobalTasks []GlobalTask
+	Charts      CaseCharts
+}
+
+// StatusCount is the number of tasks with a status
+type StatusCount struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+// GetDashboard fetches the open cases and tasks of the current user, the global tasks and the case statistics.
+//
+// Example usage:
+//
+//	dashboard, err := client.GetDashboard()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d open cases, %d closed this week\n", len(dashboard.Cases), dashboard.Charts.ClosedCases.Total())
+//	for _, count := range dashboard.TaskCounts() {
+//	    fmt.Printf("%s: %d\n", count.Status, count.Count)
+//	}
+//
+// Returns:
+// - *Dashboard*: The dashboard.
+// - error: An error if one of the requests fails.
+func (client *APIClient) GetDashboard() (*Dashboard, error) {
+	cases, err := client.ListUserCases(false)
+	if err != nil {
+		return nil, fmt.Errorf("user cases: %w", err)
+	}
+	tasks, err := client.ListUserTasks()
+	if err != nil {
+		return nil, fmt.Errorf("user tasks: %w", err)
+	}
+	globalTasks, err := client.ListGlobalTasks()
+	if err != nil {
+		return nil, fmt.Errorf("global tasks: %w", err)
+	}
+	charts, err := client.GetCaseCharts()
+	if err != nil {
+		return nil, fmt.Errorf("case charts: %w", err)
+	}
+
+	return &Dashboard{
+		Cases:       cases.Cases,
+		Tasks:       tasks.Data.Tasks,
+		GlobalTasks: globalTasks.Data.Tasks,
+		Charts:      charts.Charts,
+	}, nil
+}
+
+// TaskCounts counts the user tasks by status, most frequent first
+func (dashboard *Dashboard) TaskCounts() []StatusCount {
+	statuses := make([]string, 0, len(dashboard.Tasks))
+	for _, task := range dashboard.Tasks {
+		statuses = append(statuses, task.StatusName)
+	}
+	return statusCounts(statuses)
+}
+
+// GlobalTaskCounts counts the global tasks by status, most frequent first
+func (dashboard *Dashboard) GlobalTaskCounts() []StatusCount {
+	statuses := make([]string, 0, len(dashboard.GlobalTasks))
+	for _, task := range dashboard.GlobalTasks {
+		statuses = append(statuses, task.StatusName)
+	}
+	return statusCounts(statuses)
+}
+
+// TasksByCase groups the user tasks by the name of their case
+func (dashboard *Dashboard) TasksByCase() map[string][]UserTask {
+	byCase := make(map[string][]UserTask)
+	for _, task := range dashboard.Tasks {
+		byCase[task.TaskCase] = append(byCase[task.TaskCase], task)
+	}
+	return byCase
+}
+
+func statusCounts(statuses []string) []StatusCount {
+	counts := make(map[string]int)
+	for _, status := range statuses {
+		counts[status]++
+	}
+
+	result := make([]StatusCount, 0, len(counts))
+	for status, count := range counts {
+		result = append(result, StatusCount{Status: status, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Status < result[j].Status
+	})
+	return result
+}
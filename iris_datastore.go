@@ -0,0 +1,321 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DatastoreTreeResponse represents the response of the /datastore/list/tree endpoint
+type DatastoreTreeResponse struct {
+	Tree map[string]DatastoreNode `json:"data"`
+	ApiMeta
+}
+
+// DatastoreNode is a directory or file of the case datastore. Nodes are keyed "d-<id>" for
+// directories and "f-<id>" for files in their parent's Children.
+type DatastoreNode struct {
+	Type     string                   `json:"type"`
+	Name     string                   `json:"name"`
+	IsRoot   bool                     `json:"is_root"`
+	Children map[string]DatastoreNode `json:"children"`
+
+	FileOriginalName string `json:"file_original_name"`
+	FileDescription  string `json:"file_description"`
+	FileSize         int64  `json:"file_size"`
+	FileSHA256       string `json:"file_sha256"`
+	FileUUID         string `json:"file_uuid"`
+	FileTags         string `json:"file_tags"`
+	FileIsEvidence   bool   `json:"file_is_evidence"`
+	FileIsIoc        bool   `json:"file_is_ioc"`
+	FilePassword     string `json:"file_password"`
+}
+
+// DatastoreFile is a file of the datastore with its ID and its path inside the datastore
+type DatastoreFile struct {
+	ID   int
+	Path string
+	DatastoreNode
+}
+
+// DatastoreFolderAPIResponse represents the response of the /datastore/folder/add endpoint
+type DatastoreFolderAPIResponse struct {
+	Folder DatastoreFolder `json:"data"`
+	ApiMeta
+}
+
+// DatastoreFolder is a directory of the case datastore
+type DatastoreFolder struct {
+	PathID       int    `json:"path_id"`
+	PathName     string `json:"path_name"`
+	PathParentID int    `json:"path_parent_id"`
+}
+
+// DatastoreFileAPIResponse represents the response of the /datastore/file/add endpoint
+type DatastoreFileAPIResponse struct {
+	File struct {
+		FileID           int    `json:"file_id"`
+		FileUUID         string `json:"file_uuid"`
+		FileOriginalName string `json:"file_original_name"`
+		FileSize         int64  `json:"file_size"`
+		FileSHA256       string `json:"file_sha256"`
+	} `json:"data"`
+	ApiMeta
+}
+
+// DatastoreFileRequest represents a struct for uploading a file to the datastore, File is read until EOF
+type DatastoreFileRequest struct {
+	FileOriginalName string
+	FileDescription  string
+	FilePassword     string
+	FileTags         string
+	FileIsEvidence   bool
+	FileIsIoc        bool
+	File             io.Reader
+}
+
+// Folders returns the ID of every directory of the tree by its slash separated path, the root directory is ""
+func (response *DatastoreTreeResponse) Folders() map[string]int {
+	folders := make(map[string]int)
+
+	var walk func(dir string, children map[string]DatastoreNode)
+	walk = func(dir string, children map[string]DatastoreNode) {
+		for key, node := range children {
+			if node.Type != "directory" && !strings.HasPrefix(key, "d-") {
+				continue
+			}
+			id, err := strconv.Atoi(strings.TrimPrefix(key, "d-"))
+			if err != nil {
+				continue
+			}
+			folderPath := strings.TrimPrefix(path.Join("/", dir, node.Name), "/")
+			folders[folderPath] = id
+			walk(folderPath, node.Children)
+		}
+	}
+	for key, root := range response.Tree {
+		if id, err := strconv.Atoi(strings.TrimPrefix(key, "d-")); err == nil {
+			folders[""] = id
+		}
+		walk("", root.Children)
+	}
+
+	return folders
+}
+
+// Files returns every file of the tree with its slash separated path, sorted by path
+func (response *DatastoreTreeResponse) Files() []DatastoreFile {
+	var files []DatastoreFile
+
+	var walk func(dir string, children map[string]DatastoreNode)
+	walk = func(dir string, children map[string]DatastoreNode) {
+		for key, node := range children {
+			if node.Type == "directory" || strings.HasPrefix(key, "d-") {
+				walk(path.Join(dir, node.Name), node.Children)
+				continue
+			}
+			id, err := strconv.Atoi(strings.TrimPrefix(key, "f-"))
+			if err != nil {
+				continue
+			}
+			name := node.FileOriginalName
+			if name == "" {
+				name = node.Name
+			}
+			// joining with "/" first keeps names like "../x" inside the datastore
+			filePath := strings.TrimPrefix(path.Join("/", dir, name), "/")
+			files = append(files, DatastoreFile{ID: id, Path: filePath, DatastoreNode: node})
+		}
+	}
+	for _, root := range response.Tree {
+		// the root directory itself is not part of the paths
+		walk("", root.Children)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// GetDatastoreTree gets the datastore of a case from the /datastore/list/tree endpoint.
+//
+// Returns:
+// - *DatastoreTreeResponse*: The response from the API containing the directory tree in the Tree field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetDatastoreTree(caseId int) (*DatastoreTreeResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/datastore/list/tree").
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var treeResponse DatastoreTreeResponse
+	if err := json.NewDecoder(req.Body).Decode(&treeResponse); err != nil {
+		return nil, err
+	}
+
+	return &treeResponse, nil
+}
+
+// DownloadDatastoreFile streams the content of a datastore file from the /datastore/file/view/<file-id> endpoint into w.
+//
+// Returns:
+// - int64: The number of bytes written.
+// - error: An error if the request fails or the content cannot be written to w.
+func (client *APIClient) DownloadDatastoreFile(caseId int, fileId int, w io.Writer) (int64, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/datastore/file/view/%d", fileId)).
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return 0, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return io.Copy(w, req.Body)
+}
+
+// AddDatastoreFolder creates a directory in the datastore of a case through the /datastore/folder/add endpoint.
+//
+// Returns:
+// - *DatastoreFolderAPIResponse*: The response from the API containing the new directory in the Folder field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddDatastoreFolder(caseId int, parentId int, name string) (*DatastoreFolderAPIResponse, error) {
+	jsondata, err := json.Marshal(map[string]interface{}{
+		"parent_node": parentId,
+		"folder_name": name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/datastore/folder/add").
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var folderResponse DatastoreFolderAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&folderResponse); err != nil {
+		return nil, err
+	}
+
+	return &folderResponse, nil
+}
+
+// AddDatastoreFile uploads a file into a directory of the datastore of a case through the
+// /datastore/file/add/<folder-id> endpoint. The content of File is streamed into the request, an error
+// reading it aborts the upload.
+//
+// Example usage:
+//
+//	file, _ := os.Open("memory.dmp")
+//	defer file.Close()
+//	tree, _ := client.GetDatastoreTree(42)
+//	uploaded, err := client.AddDatastoreFile(42, tree.Folders()["Evidences"], goiris.DatastoreFileRequest{
+//		FileOriginalName: "memory.dmp",
+//		FileIsEvidence:   true,
+//		File:             file,
+//	})
+//
+// Returns:
+// - *DatastoreFileAPIResponse*: The response from the API containing the uploaded file in the File field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddDatastoreFile(caseId int, folderId int, file DatastoreFileRequest) (*DatastoreFileAPIResponse, error) {
+	// the body is streamed, file is read while the request is sent
+	body, pipe := io.Pipe()
+	defer body.Close()
+	writer := multipart.NewWriter(pipe)
+	go func() {
+		pipe.CloseWithError(writeDatastoreFile(writer, file))
+	}()
+
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/datastore/file/add/%d", folderId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", writer.FormDataContentType()).
+		SetBody(body).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var fileResponse DatastoreFileAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&fileResponse); err != nil {
+		return nil, err
+	}
+
+	return &fileResponse, nil
+}
+
+// writeDatastoreFile writes the form of AddDatastoreFile
+func writeDatastoreFile(writer *multipart.Writer, file DatastoreFileRequest) error {
+	fields := map[string]string{
+		"file_original_name": file.FileOriginalName,
+		"file_description":   file.FileDescription,
+		"file_password":      file.FilePassword,
+		"file_tags":          file.FileTags,
+	}
+	if file.FileIsEvidence {
+		fields["file_is_evidence"] = "y"
+	}
+	if file.FileIsIoc {
+		fields["file_is_ioc"] = "y"
+	}
+	for key, value := range fields {
+		if err := writer.WriteField(key, value); err != nil {
+			return err
+		}
+	}
+
+	part, err := writer.CreateFormFile("file_content", file.FileOriginalName)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, file.File); err != nil {
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,182 @@
+package goiris
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Celery states reported by IRIS for DIM (module) tasks
+const (
+	DimTaskPending = "PENDING"
+	DimTaskStarted = "STARTED"
+	DimTaskRetry   = "RETRY"
+	DimTaskSuccess = "SUCCESS"
+	DimTaskFailure = "FAILURE"
+	DimTaskRevoked = "REVOKED"
+)
+
+// ErrDimTaskFailed is returned by WaitForTask when the task ended in a failed or revoked state
+var ErrDimTaskFailed = errors.New("dim task failed")
+
+// DimTasksResponse represents the response of the /dim/tasks/list endpoint
+type DimTasksResponse struct {
+	Tasks []DimTask `json:"data"`
+	ApiMeta
+}
+
+// DimTaskResponse represents the response of the /dim/tasks/status endpoint
+type DimTaskResponse struct {
+	Task DimTask `json:"data"`
+	ApiMeta
+}
+
+// DimTask represents a single task processed by the IRIS module interface
+type DimTask struct {
+	TaskID    string          `json:"task_id"`
+	State     string          `json:"state"`
+	Module    string          `json:"module"`
+	Case      string          `json:"case"`
+	User      string          `json:"user"`
+	DateDone  string          `json:"date_done"`
+	Success   bool            `json:"success"`
+	Logs      []string        `json:"logs"`
+	Traceback string          `json:"traceback"`
+	Result    json.RawMessage `json:"result"`
+}
+
+// Done reports whether the task reached a final state
+func (task *DimTask) Done() bool {
+	switch task.State {
+	case DimTaskSuccess, DimTaskFailure, DimTaskRevoked:
+		return true
+	}
+	return false
+}
+
+// Failed reports whether the task ended unsuccessfully
+func (task *DimTask) Failed() bool {
+	return task.State == DimTaskFailure || task.State == DimTaskRevoked
+}
+
+// ListDimTasks gets the last count DIM tasks from the /dim/tasks/list/<count> endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Example usage:
+//
+//	tasks, err := client.ListDimTasks(50)
+//	if err != nil {
+//	    log.Fatalf("Failed to list tasks: %v", err)
+//	}
+//	for _, task := range tasks.Tasks {
+//		fmt.Println(task.TaskID, task.State)
+//	}
+//
+// Returns:
+// - *DimTasksResponse*: The response from the API containing the tasks in the Tasks field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListDimTasks(count int) (*DimTasksResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/dim/tasks/list/%d", count)).
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var tasksResponse DimTasksResponse
+	if err := json.NewDecoder(req.Body).Decode(&tasksResponse); err != nil {
+		return nil, err
+	}
+
+	return &tasksResponse, nil
+}
+
+// GetDimTask gets the status and result of a single task from the /dim/tasks/status/<task-id> endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *DimTaskResponse*: The response from the API containing the task in the Task field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetDimTask(taskId string) (*DimTaskResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/dim/tasks/status/%s", taskId)).
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var taskResponse DimTaskResponse
+	if err := json.NewDecoder(req.Body).Decode(&taskResponse); err != nil {
+		return nil, err
+	}
+
+	return &taskResponse, nil
+}
+
+// WaitForTask polls GetDimTask every pollInterval until the task reaches a final state
+// or ctx is done. Use context.WithTimeout to bound the wait.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+//	defer cancel()
+//	task, err := client.WaitForTask(ctx, taskId, 2*time.Second)
+//	if err != nil {
+//	    log.Fatalf("Module did not succeed: %v", err)
+//	}
+//	fmt.Println(string(task.Result))
+//
+// Returns:
+// - *DimTask*: The last known state of the task, also on failure or timeout if it was fetched at least once.
+// - error: ErrDimTaskFailed if the task failed, the context error on timeout or any request error.
+func (client *APIClient) WaitForTask(ctx context.Context, taskId string, pollInterval time.Duration) (*DimTask, error) {
+	if pollInterval <= 0 {
+		return nil, fmt.Errorf("invalid poll interval: %s", pollInterval)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var last *DimTask
+	for {
+		taskResponse, err := client.GetDimTask(taskId)
+		if err != nil {
+			return last, err
+		}
+		last = &taskResponse.Task
+
+		if last.Done() {
+			if last.Failed() {
+				return last, fmt.Errorf("%w: task %s ended in state %s", ErrDimTaskFailed, taskId, last.State)
+			}
+			return last, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
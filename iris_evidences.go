@@ -0,0 +1,204 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// EvidencesResponse represents the response of the /case/evidences/list endpoint
+type EvidencesResponse struct {
+	Data struct {
+		Evidences []Evidence `json:"evidences"`
+	} `json:"data"`
+	ApiMeta
+}
+
+// EvidenceAPIResponse represents the response of a single evidence api action
+type EvidenceAPIResponse struct {
+	Evidence Evidence `json:"data"`
+	ApiMeta
+}
+
+// Evidence represents a piece of evidence registered in a case
+type Evidence struct {
+	ID               int              `json:"id"`
+	FileUUID         string           `json:"file_uuid"`
+	Filename         string           `json:"filename"`
+	FileSize         int64            `json:"file_size"`
+	FileHash         string           `json:"file_hash"`
+	FileDescription  string           `json:"file_description"`
+	TypeID           int              `json:"type_id"`
+	DateAdded        string           `json:"date_added"`
+	CustomAttributes CustomAttributes `json:"custom_attributes"`
+}
+
+// EvidenceRequest represents a struct for adding or updating an evidence
+type EvidenceRequest struct {
+	Filename         string          `json:"filename"`
+	FileSize         int64           `json:"file_size"`
+	FileHash         string          `json:"file_hash,omitempty"`
+	FileDescription  string          `json:"file_description,omitempty"`
+	TypeID           int             `json:"type_id,omitempty"`
+	CustomAttributes AttributeValues `json:"custom_attributes,omitempty"`
+}
+
+// ListEvidences gets all evidences of a case from the /case/evidences/list endpoint.
+//
+// Returns:
+// - *EvidencesResponse*: The response from the API containing the evidences in the Data.Evidences field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListEvidences(caseId int) (*EvidencesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/case/evidences/list").
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var evidencesResponse EvidencesResponse
+	if err := json.NewDecoder(req.Body).Decode(&evidencesResponse); err != nil {
+		return nil, err
+	}
+
+	return &evidencesResponse, nil
+}
+
+// GetEvidence returns a single evidence from the /case/evidences/<evidence-id> endpoint.
+//
+// Returns:
+// - *EvidenceAPIResponse*: The response from the API containing the evidence in the Evidence field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetEvidence(caseId int, evidenceId int) (*EvidenceAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/evidences/%d", evidenceId)).
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var evidenceResponse EvidenceAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&evidenceResponse); err != nil {
+		return nil, err
+	}
+
+	return &evidenceResponse, nil
+}
+
+// AddEvidence registers an evidence in a case through the /case/evidences/add endpoint.
+//
+// Returns:
+// - *EvidenceAPIResponse*: The response from the API containing the created evidence in the Evidence field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddEvidence(caseId int, evidence EvidenceRequest) (*EvidenceAPIResponse, error) {
+	jsondata, err := json.Marshal(evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/case/evidences/add").
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var evidenceResponse EvidenceAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&evidenceResponse); err != nil {
+		return nil, err
+	}
+
+	return &evidenceResponse, nil
+}
+
+// UpdateEvidence updates an evidence through the /case/evidences/update/<evidence-id> endpoint.
+//
+// Returns:
+// - *EvidenceAPIResponse*: The response from the API containing the updated evidence in the Evidence field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateEvidence(caseId int, evidenceId int, evidence EvidenceRequest) (*EvidenceAPIResponse, error) {
+	jsondata, err := json.Marshal(evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/evidences/update/%d", evidenceId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var evidenceResponse EvidenceAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&evidenceResponse); err != nil {
+		return nil, err
+	}
+
+	return &evidenceResponse, nil
+}
+
+// DeleteEvidence removes an evidence using the /case/evidences/delete/<evidence-id> endpoint.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) DeleteEvidence(caseId int, evidenceId int) error {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/evidences/delete/%d", evidenceId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return nil
+}
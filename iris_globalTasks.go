@@ -0,0 +1,257 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GlobalTasksResponse represents the response of the /global/tasks/list endpoint
+type GlobalTasksResponse struct {
+	Data struct {
+		Tasks        []GlobalTask `json:"tasks"`
+		TaskStatuses []TaskStatus `json:"tasks_status"`
+	} `json:"data"`
+	ApiMeta
+}
+
+// GlobalTaskAPIResponse represents the response of a single global task api action
+type GlobalTaskAPIResponse struct {
+	Task GlobalTask `json:"data"`
+	ApiMeta
+}
+
+// GlobalTask represents a task that does not belong to a case. Unlike case tasks it has a single assignee.
+type GlobalTask struct {
+	TaskID           int              `json:"task_id"`
+	TaskUUID         string           `json:"task_uuid"`
+	TaskTitle        string           `json:"task_title"`
+	TaskDescription  string           `json:"task_description"`
+	TaskTags         string           `json:"task_tags"`
+	TaskStatusID     int              `json:"task_status_id"`
+	StatusName       string           `json:"status_name"`
+	StatusBsColor    string           `json:"status_bscolor"`
+	TaskAssigneeID   int              `json:"task_assignee_id"`
+	UserName         string           `json:"user_name"`
+	TaskOpenDate     string           `json:"task_open_date"`
+	TaskLastUpdate   string           `json:"task_last_update"`
+	CustomAttributes CustomAttributes `json:"custom_attributes"`
+}
+
+// GlobalTaskRequest represents a struct for adding or updating a global task. IRIS replaces every field on
+// update, start from GlobalTask.Request to change a single one.
+type GlobalTaskRequest struct {
+	TaskTitle        string          `json:"task_title"`
+	TaskStatusID     int             `json:"task_status_id"`
+	TaskAssigneeID   int             `json:"task_assignee_id"`
+	TaskDescription  string          `json:"task_description,omitempty"`
+	TaskTags         string          `json:"task_tags,omitempty"`
+	CustomAttributes AttributeValues `json:"custom_attributes,omitempty"`
+}
+
+// Request returns the update request that keeps the task as it is
+func (task GlobalTask) Request() GlobalTaskRequest {
+	return GlobalTaskRequest{
+		TaskTitle:        task.TaskTitle,
+		TaskStatusID:     task.TaskStatusID,
+		TaskAssigneeID:   task.TaskAssigneeID,
+		TaskDescription:  task.TaskDescription,
+		TaskTags:         task.TaskTags,
+		CustomAttributes: task.CustomAttributes.Values(),
+	}
+}
+
+// ListGlobalTasks gets all global tasks from the /global/tasks/list endpoint.
+//
+// Returns:
+// - *GlobalTasksResponse*: The response from the API containing the tasks in the Data.Tasks field and the
+// available statuses in Data.TaskStatuses.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListGlobalTasks() (*GlobalTasksResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/global/tasks/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var globalTasksResponse GlobalTasksResponse
+	if err := json.NewDecoder(req.Body).Decode(&globalTasksResponse); err != nil {
+		return nil, err
+	}
+
+	return &globalTasksResponse, nil
+}
+
+// GetGlobalTask returns a single global task, it is looked up in the /global/tasks/list endpoint.
+//
+// Returns:
+// - *GlobalTask*: The task.
+// - error: ErrLookupNotFound if there is no task with this ID, or an error if the request fails.
+func (client *APIClient) GetGlobalTask(taskId int) (*GlobalTask, error) {
+	tasks, err := client.ListGlobalTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks.Data.Tasks {
+		if task.TaskID == taskId {
+			return &task, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: global task %d", ErrLookupNotFound, taskId)
+}
+
+// AddGlobalTask creates a global task through the /global/tasks/add endpoint.
+//
+// Example usage:
+//
+//	status, _ := client.Lookup().TaskStatusID("To do")
+//	assignee, _ := client.Lookup().UserID("analyst")
+//	task, err := client.AddGlobalTask(goiris.GlobalTaskRequest{
+//	    TaskTitle:      "Patch the VPN gateways",
+//	    TaskStatusID:   status,
+//	    TaskAssigneeID: assignee,
+//	})
+//
+// Returns:
+// - *GlobalTaskAPIResponse*: The response from the API containing the new task in the Task field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddGlobalTask(task GlobalTaskRequest) (*GlobalTaskAPIResponse, error) {
+	jsondata, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/global/tasks/add").
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var globalTaskResponse GlobalTaskAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&globalTaskResponse); err != nil {
+		return nil, err
+	}
+
+	return &globalTaskResponse, nil
+}
+
+// UpdateGlobalTask updates a global task through the /global/tasks/update/<task-id> endpoint.
+//
+// Returns:
+// - *GlobalTaskAPIResponse*: The response from the API containing the updated task in the Task field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateGlobalTask(taskId int, task GlobalTaskRequest) (*GlobalTaskAPIResponse, error) {
+	jsondata, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/global/tasks/update/%d", taskId)).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var globalTaskResponse GlobalTaskAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&globalTaskResponse); err != nil {
+		return nil, err
+	}
+
+	return &globalTaskResponse, nil
+}
+
+// DeleteGlobalTask removes a global task using the /global/tasks/delete/<task-id> endpoint.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) DeleteGlobalTask(taskId int) error {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/global/tasks/delete/%d", taskId)).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return nil
+}
+
+// SetGlobalTaskStatus changes the status of a global task by status name, keeping its other fields.
+//
+// Returns:
+// - *GlobalTaskAPIResponse*: The response from the API containing the updated task in the Task field.
+// - error: ErrLookupNotFound if the task or status does not exist, or an error if a request fails.
+func (client *APIClient) SetGlobalTaskStatus(taskId int, status string) (*GlobalTaskAPIResponse, error) {
+	statusId, err := client.Lookup().TaskStatusID(status)
+	if err != nil {
+		return nil, err
+	}
+	task, err := client.GetGlobalTask(taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	request := task.Request()
+	request.TaskStatusID = statusId
+	return client.UpdateGlobalTask(taskId, request)
+}
+
+// AssignGlobalTask assigns a global task to the user with the given login, keeping its other fields.
+//
+// Returns:
+// - *GlobalTaskAPIResponse*: The response from the API containing the updated task in the Task field.
+// - error: ErrLookupNotFound if the task or user does not exist, or an error if a request fails.
+func (client *APIClient) AssignGlobalTask(taskId int, login string) (*GlobalTaskAPIResponse, error) {
+	userId, err := client.Lookup().UserID(login)
+	if err != nil {
+		return nil, err
+	}
+	task, err := client.GetGlobalTask(taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	request := task.Request()
+	request.TaskAssigneeID = userId
+	return client.UpdateGlobalTask(taskId, request)
+}
@@ -0,0 +1,95 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GroupsResponse represents the response of the /manage/groups/list endpoint
+type GroupsResponse struct {
+	Groups []Group `json:"data"`
+	ApiMeta
+}
+
+// GroupAPIResponse represents the response of a single group api action
+type GroupAPIResponse struct {
+	Group Group `json:"data"`
+	ApiMeta
+}
+
+// Group represents an IRIS group. Members and case access are only filled by GetGroup.
+type Group struct {
+	GroupID          int           `json:"group_id"`
+	GroupUUID        string        `json:"group_uuid"`
+	GroupName        string        `json:"group_name"`
+	GroupDescription string        `json:"group_description"`
+	GroupPermissions int           `json:"group_permissions"`
+	GroupMembers     []GroupMember `json:"group_members"`
+	GroupCasesAccess []CaseAccess  `json:"group_cases_access"`
+}
+
+// GroupMember is a user in a group
+type GroupMember struct {
+	ID   int    `json:"id"`
+	User string `json:"user"`
+	Name string `json:"name"`
+}
+
+// ListGroups gets all groups of the instance from the /manage/groups/list endpoint.
+//
+// Returns:
+// - *GroupsResponse*: The response from the API containing the groups in the Groups field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListGroups() (*GroupsResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/groups/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var groupsResponse GroupsResponse
+	if err := json.NewDecoder(req.Body).Decode(&groupsResponse); err != nil {
+		return nil, err
+	}
+
+	return &groupsResponse, nil
+}
+
+// GetGroup returns a single group with its members and case access from the /manage/groups/<group-id> endpoint.
+//
+// Returns:
+// - *GroupAPIResponse*: The response from the API containing the group in the Group field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetGroup(groupId int) (*GroupAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/groups/%d", groupId)).
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var groupResponse GroupAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&groupResponse); err != nil {
+		return nil, err
+	}
+
+	return &groupResponse, nil
+}
@@ -0,0 +1,201 @@
+package goiris
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// ErrIocValueInvalid is returned when an IOC value does not match the validation regex of its type
+var ErrIocValueInvalid = errors.New("ioc value does not match type validation")
+
+// ErrIocTypeRegexUnsupported is returned when the validation regex of an IOC type uses Python syntax RE2 cannot
+// compile, the value cannot be validated locally and is left to IRIS
+var ErrIocTypeRegexUnsupported = errors.New("ioc type validation regex not supported locally")
+
+// IocTypeAPIResponse represents the response of a single IOC type api action
+type IocTypeAPIResponse struct {
+	IocType IocType `json:"data"`
+	ApiMeta
+}
+
+// IocTypeRequest represents a struct for adding or updating an IOC type.
+// TypeValidationExpect is the hint shown to analysts when a value does not match TypeValidationRegex.
+type IocTypeRequest struct {
+	TypeName             string `json:"type_name"`
+	TypeDescription      string `json:"type_description"`
+	TypeTaxonomy         string `json:"type_taxonomy"`
+	TypeValidationRegex  string `json:"type_validation_regex"`
+	TypeValidationExpect string `json:"type_validation_expect"`
+}
+
+// GetIocType gets a single IOC type from the /manage/ioc-types/<id> endpoint.
+//
+// Returns:
+// - *IocTypeAPIResponse*: The response from the API containing the IOC type in the IocType field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetIocType(iocTypeId int) (*IocTypeAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/ioc-types/%d", iocTypeId)).
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var iocTypeResponse IocTypeAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&iocTypeResponse); err != nil {
+		return nil, err
+	}
+
+	return &iocTypeResponse, nil
+}
+
+// AddIocType adds an IOC type through the /manage/ioc-types/add endpoint.
+// IRIS checks the validation regex with Python, so a regex RE2 cannot compile is still submitted; ValidateIocValue
+// reports ErrIocTypeRegexUnsupported for such types.
+//
+// Example usage:
+//
+//	iocType, err := client.AddIocType(goiris.IocTypeRequest{
+//		TypeName:             "jarm",
+//		TypeDescription:      "JARM TLS fingerprint",
+//		TypeValidationRegex:  "[0-9a-f]{62}",
+//		TypeValidationExpect: "62 hexadecimal characters",
+//	})
+//
+// Returns:
+// - *IocTypeAPIResponse*: The response from the API containing the created IOC type in the IocType field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddIocType(iocType IocTypeRequest) (*IocTypeAPIResponse, error) {
+	return client.submitIocType("/manage/ioc-types/add", iocType)
+}
+
+// UpdateIocType updates an IOC type through the /manage/ioc-types/update/<id> endpoint.
+//
+// Returns:
+// - *IocTypeAPIResponse*: The response from the API containing the updated IOC type in the IocType field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateIocType(iocTypeId int, iocType IocTypeRequest) (*IocTypeAPIResponse, error) {
+	return client.submitIocType(fmt.Sprintf("/manage/ioc-types/update/%d", iocTypeId), iocType)
+}
+
+// DeleteIocType removes an IOC type using the /manage/ioc-types/delete/<id> endpoint.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) DeleteIocType(iocTypeId int) error {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/ioc-types/delete/%d", iocTypeId)).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	client.Lookup().Invalidate(LookupIocType)
+	return nil
+}
+
+// ValidateIocValue checks value against the validation regex of the IOC type iocTypeId
+// before it is submitted to IRIS.
+//
+// Example usage:
+//
+//	iocTypeId, _ := client.Lookup().IocTypeID("ip-dst")
+//	if err := client.ValidateIocValue(iocTypeId, "10.0.0.300"); err != nil {
+//	    log.Println(err) // ioc value does not match type validation: ...
+//	}
+//
+// Returns:
+// - error: ErrIocValueInvalid if the value does not match, ErrIocTypeRegexUnsupported if the regex cannot be checked
+// locally, or an error if the type cannot be fetched.
+func (client *APIClient) ValidateIocValue(iocTypeId int, value string) error {
+	iocTypeResponse, err := client.GetIocType(iocTypeId)
+	if err != nil {
+		return err
+	}
+
+	return ValidateIocValue(iocTypeResponse.IocType, value)
+}
+
+// ValidateIocValue checks value against the validation regex of iocType the way IRIS does,
+// i.e. the whole value has to match and case is ignored. Types without a regex accept any value.
+// IRIS evaluates the regex with Python, for patterns using features RE2 lacks (e.g. lookarounds)
+// ErrIocTypeRegexUnsupported is returned, the value is then only validated by IRIS.
+func ValidateIocValue(iocType IocType, value string) error {
+	if iocType.TypeValidationRegex == "" {
+		return nil
+	}
+
+	re, err := compileIocTypeRegex(iocType.TypeValidationRegex)
+	if err != nil {
+		return err
+	}
+
+	if !re.MatchString(value) {
+		if iocType.TypeValidationExpect != "" {
+			return fmt.Errorf("%w: %q is not a valid %s, expected %s", ErrIocValueInvalid, value, iocType.TypeName, iocType.TypeValidationExpect)
+		}
+		return fmt.Errorf("%w: %q is not a valid %s", ErrIocValueInvalid, value, iocType.TypeName)
+	}
+
+	return nil
+}
+
+func compileIocTypeRegex(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(`(?i)^(?:` + pattern + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIocTypeRegexUnsupported, err)
+	}
+	return re, nil
+}
+
+func (client *APIClient) submitIocType(endpoint string, iocType IocTypeRequest) (*IocTypeAPIResponse, error) {
+	jsondata, err := json.Marshal(iocType)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(endpoint).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var iocTypeResponse IocTypeAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&iocTypeResponse); err != nil {
+		return nil, err
+	}
+
+	client.Lookup().Invalidate(LookupIocType)
+	return &iocTypeResponse, nil
+}
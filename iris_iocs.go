@@ -0,0 +1,219 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// IocsResponse represents the response of the /case/ioc/list endpoint
+type IocsResponse struct {
+	Data struct {
+		Iocs []Ioc `json:"ioc"`
+	} `json:"data"`
+	ApiMeta
+}
+
+// IocAPIResponse represents the response of a single IOC api action
+type IocAPIResponse struct {
+	Ioc Ioc `json:"data"`
+	ApiMeta
+}
+
+// Ioc represents an indicator of compromise of a case. IocType and TlpName are only filled by the list endpoint.
+type Ioc struct {
+	IocID            int              `json:"ioc_id"`
+	IocUUID          string           `json:"ioc_uuid"`
+	IocValue         string           `json:"ioc_value"`
+	IocDescription   string           `json:"ioc_description"`
+	IocTags          string           `json:"ioc_tags"`
+	IocTypeID        int              `json:"ioc_type_id"`
+	IocType          string           `json:"ioc_type"`
+	IocTlpID         int              `json:"ioc_tlp_id"`
+	TlpName          string           `json:"tlp_name"`
+	CustomAttributes CustomAttributes `json:"custom_attributes"`
+}
+
+// IocRequest represents a struct for adding or updating an IOC of a case
+type IocRequest struct {
+	IocValue         string          `json:"ioc_value"`
+	IocTypeID        int             `json:"ioc_type_id"`
+	IocTlpID         int             `json:"ioc_tlp_id"`
+	IocDescription   string          `json:"ioc_description,omitempty"`
+	IocTags          string          `json:"ioc_tags,omitempty"`
+	CustomAttributes AttributeValues `json:"custom_attributes,omitempty"`
+}
+
+// ListIocs gets all IOCs of a case from the /case/ioc/list endpoint.
+//
+// Returns:
+// - *IocsResponse*: The response from the API containing the IOCs in the Data.Iocs field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListIocs(caseId int) (*IocsResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/case/ioc/list").
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var iocsResponse IocsResponse
+	if err := json.NewDecoder(req.Body).Decode(&iocsResponse); err != nil {
+		return nil, err
+	}
+
+	return &iocsResponse, nil
+}
+
+// GetIoc returns a single IOC of a case from the /case/ioc/<ioc-id> endpoint.
+//
+// Returns:
+// - *IocAPIResponse*: The response from the API containing the IOC in the Ioc field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetIoc(caseId int, iocId int) (*IocAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/ioc/%d", iocId)).
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var iocResponse IocAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&iocResponse); err != nil {
+		return nil, err
+	}
+
+	return &iocResponse, nil
+}
+
+// AddIoc adds an IOC to a case through the /case/ioc/add endpoint.
+// Use ValidateIocValue to check the value against its type before submitting it.
+//
+// Example usage:
+//
+//	iocTypeId, _ := client.Lookup().IocTypeID("ip-dst")
+//	tlpId, _ := client.Lookup().TlpID("amber")
+//	if err := client.ValidateIocValue(iocTypeId, "203.0.113.7"); err != nil {
+//	    log.Fatal(err)
+//	}
+//	ioc, err := client.AddIoc(42, goiris.IocRequest{
+//		IocValue:  "203.0.113.7",
+//		IocTypeID: iocTypeId,
+//		IocTlpID:  tlpId,
+//	})
+//
+// Returns:
+// - *IocAPIResponse*: The response from the API containing the created IOC in the Ioc field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddIoc(caseId int, ioc IocRequest) (*IocAPIResponse, error) {
+	jsondata, err := json.Marshal(ioc)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/case/ioc/add").
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var iocResponse IocAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&iocResponse); err != nil {
+		return nil, err
+	}
+
+	return &iocResponse, nil
+}
+
+// UpdateIoc updates an IOC of a case through the /case/ioc/update/<ioc-id> endpoint.
+//
+// Returns:
+// - *IocAPIResponse*: The response from the API containing the updated IOC in the Ioc field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateIoc(caseId int, iocId int, ioc IocRequest) (*IocAPIResponse, error) {
+	jsondata, err := json.Marshal(ioc)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/ioc/update/%d", iocId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var iocResponse IocAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&iocResponse); err != nil {
+		return nil, err
+	}
+
+	return &iocResponse, nil
+}
+
+// DeleteIoc removes an IOC from a case using the /case/ioc/delete/<ioc-id> endpoint.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) DeleteIoc(caseId int, iocId int) error {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/ioc/delete/%d", iocId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return nil
+}
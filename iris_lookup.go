@@ -0,0 +1,324 @@
+package goiris
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLookupTTL is the time cached reference data is reused before it is fetched again
+const DefaultLookupTTL = 15 * time.Minute
+
+// ErrLookupNotFound is returned when a name or ID is not known by the instance
+var ErrLookupNotFound = errors.New("lookup: not found")
+
+// LookupKind identifies a kind of reference data
+type LookupKind string
+
+const (
+	LookupAssetType          LookupKind = "asset type"
+	LookupIocType            LookupKind = "ioc type"
+	LookupCaseClassification LookupKind = "case classification"
+	LookupCaseState          LookupKind = "case state"
+	LookupEvidenceType       LookupKind = "evidence type"
+	LookupEventCategory      LookupKind = "event category"
+	LookupAnalysisStatus     LookupKind = "analysis status"
+	LookupTlp                LookupKind = "tlp"
+	LookupSeverity           LookupKind = "severity"
+	LookupTaskStatus         LookupKind = "task status"
+	LookupUser               LookupKind = "user" // users are matched by login
+	LookupGroup              LookupKind = "group"
+)
+
+// Lookup resolves the human readable names of reference data to the integer IDs IRIS expects
+// and back. Every kind is fetched on first use and cached for TTL. Names are matched case-insensitively.
+// A Lookup is safe for concurrent use.
+type Lookup struct {
+	client *APIClient
+	TTL    time.Duration
+
+	mu     sync.Mutex
+	tables map[LookupKind]*lookupTable
+}
+
+type lookupTable struct {
+	fetchedAt time.Time
+	ids       map[string]int
+	names     map[int]string
+}
+
+var lookupInit sync.Mutex
+
+// NewLookup returns a Lookup with its own cache, using ttl as time to live
+func NewLookup(client *APIClient, ttl time.Duration) *Lookup {
+	return &Lookup{
+		client: client,
+		TTL:    ttl,
+		tables: make(map[LookupKind]*lookupTable),
+	}
+}
+
+// Lookup returns the Lookup shared by everything using this client, created with DefaultLookupTTL on first use.
+//
+// Example usage:
+//
+//	assetTypeId, err := client.Lookup().AssetTypeID("Windows - Computer")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	tlpId, err := client.Lookup().TlpID("amber")
+func (client *APIClient) Lookup() *Lookup {
+	lookupInit.Lock()
+	defer lookupInit.Unlock()
+
+	if client.lookup == nil {
+		client.lookup = NewLookup(client, DefaultLookupTTL)
+	}
+	return client.lookup
+}
+
+// ID returns the ID of the entry called name of the given kind
+func (lookup *Lookup) ID(kind LookupKind, name string) (int, error) {
+	table, err := lookup.table(kind)
+	if err != nil {
+		return 0, err
+	}
+
+	id, ok := table.ids[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s %q", ErrLookupNotFound, kind, name)
+	}
+	return id, nil
+}
+
+// Name returns the name of the entry with the given kind and ID
+func (lookup *Lookup) Name(kind LookupKind, id int) (string, error) {
+	table, err := lookup.table(kind)
+	if err != nil {
+		return "", err
+	}
+
+	name, ok := table.names[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %s %d", ErrLookupNotFound, kind, id)
+	}
+	return name, nil
+}
+
+// Refresh fetches the given kinds again right away, all cached kinds if none are given
+func (lookup *Lookup) Refresh(kinds ...LookupKind) error {
+	lookup.mu.Lock()
+	defer lookup.mu.Unlock()
+
+	if len(kinds) == 0 {
+		for kind := range lookup.tables {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	for _, kind := range kinds {
+		table, err := lookup.fetch(kind)
+		if err != nil {
+			return err
+		}
+		lookup.tables[kind] = table
+	}
+	return nil
+}
+
+// Invalidate drops the cache of the given kinds, all kinds if none are given.
+// They are fetched again on next use.
+func (lookup *Lookup) Invalidate(kinds ...LookupKind) {
+	lookup.mu.Lock()
+	defer lookup.mu.Unlock()
+
+	if len(kinds) == 0 {
+		lookup.tables = make(map[LookupKind]*lookupTable)
+		return
+	}
+	for _, kind := range kinds {
+		delete(lookup.tables, kind)
+	}
+}
+
+func (lookup *Lookup) AssetTypeID(name string) (int, error) {
+	return lookup.ID(LookupAssetType, name)
+}
+
+func (lookup *Lookup) IocTypeID(name string) (int, error) {
+	return lookup.ID(LookupIocType, name)
+}
+
+func (lookup *Lookup) CaseClassificationID(name string) (int, error) {
+	return lookup.ID(LookupCaseClassification, name)
+}
+
+func (lookup *Lookup) CaseStateID(name string) (int, error) {
+	return lookup.ID(LookupCaseState, name)
+}
+
+func (lookup *Lookup) EvidenceTypeID(name string) (int, error) {
+	return lookup.ID(LookupEvidenceType, name)
+}
+
+func (lookup *Lookup) EventCategoryID(name string) (int, error) {
+	return lookup.ID(LookupEventCategory, name)
+}
+
+func (lookup *Lookup) AnalysisStatusID(name string) (int, error) {
+	return lookup.ID(LookupAnalysisStatus, name)
+}
+
+func (lookup *Lookup) TlpID(name string) (int, error) {
+	return lookup.ID(LookupTlp, name)
+}
+
+func (lookup *Lookup) SeverityID(name string) (int, error) {
+	return lookup.ID(LookupSeverity, name)
+}
+
+func (lookup *Lookup) TaskStatusID(name string) (int, error) {
+	return lookup.ID(LookupTaskStatus, name)
+}
+
+func (lookup *Lookup) UserID(login string) (int, error) {
+	return lookup.ID(LookupUser, login)
+}
+
+func (lookup *Lookup) GroupID(name string) (int, error) {
+	return lookup.ID(LookupGroup, name)
+}
+
+func (lookup *Lookup) table(kind LookupKind) (*lookupTable, error) {
+	lookup.mu.Lock()
+	defer lookup.mu.Unlock()
+
+	if table, ok := lookup.tables[kind]; ok && time.Since(table.fetchedAt) < lookup.TTL {
+		return table, nil
+	}
+
+	table, err := lookup.fetch(kind)
+	if err != nil {
+		return nil, err
+	}
+	lookup.tables[kind] = table
+	return table, nil
+}
+
+func (lookup *Lookup) fetch(kind LookupKind) (*lookupTable, error) {
+	table := &lookupTable{
+		fetchedAt: time.Now(),
+		ids:       make(map[string]int),
+		names:     make(map[int]string),
+	}
+	add := func(id int, name string) {
+		table.ids[strings.ToLower(strings.TrimSpace(name))] = id
+		table.names[id] = name
+	}
+
+	client := lookup.client
+	switch kind {
+	case LookupAssetType:
+		response, err := client.ListAssetTypes()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range response.AssetTypes {
+			add(entry.AssetID, entry.AssetName)
+		}
+	case LookupIocType:
+		response, err := client.ListIocTypes()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range response.IocTypes {
+			add(entry.TypeID, entry.TypeName)
+		}
+	case LookupCaseClassification:
+		response, err := client.ListCaseClassifications()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range response.CaseClassifications {
+			add(entry.ID, entry.Name)
+		}
+	case LookupCaseState:
+		response, err := client.ListCaseStates()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range response.CaseStates {
+			add(entry.StateID, entry.StateName)
+		}
+	case LookupEvidenceType:
+		response, err := client.ListEvidenceTypes()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range response.EvidenceTypes {
+			add(entry.ID, entry.Name)
+		}
+	case LookupEventCategory:
+		response, err := client.ListEventCategories()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range response.EventCategories {
+			add(entry.ID, entry.Name)
+		}
+	case LookupAnalysisStatus:
+		response, err := client.ListAnalysisStatuses()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range response.AnalysisStatuses {
+			add(entry.ID, entry.Name)
+		}
+	case LookupTlp:
+		response, err := client.ListTlps()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range response.Tlps {
+			add(entry.TlpID, entry.TlpName)
+		}
+	case LookupSeverity:
+		response, err := client.ListSeverities()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range response.Severities {
+			add(entry.SeverityID, entry.SeverityName)
+		}
+	case LookupTaskStatus:
+		response, err := client.ListTaskStatuses()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range response.TaskStatuses {
+			add(entry.ID, entry.StatusName)
+		}
+	case LookupUser:
+		response, err := client.ListUsers()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range response.Users {
+			add(entry.UserID, entry.UserLogin)
+		}
+	case LookupGroup:
+		response, err := client.ListGroups()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range response.Groups {
+			add(entry.GroupID, entry.GroupName)
+		}
+	default:
+		return nil, fmt.Errorf("unknown lookup kind: %s", kind)
+	}
+
+	return table, nil
+}
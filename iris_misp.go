@@ -0,0 +1,591 @@
+package goiris
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// MispTypes maps IRIS IOC types that MISP does not know to a MISP attribute type.
+// All other IOC types of IRIS are MISP types already and are exported unchanged.
+var MispTypes = map[string]string{
+	"ip-any": "ip-dst",
+}
+
+// mispCategories is the MISP category used for the attribute types, other types are exported as "Other"
+var mispCategories = map[string]string{
+	"ip-src":          "Network activity",
+	"ip-dst":          "Network activity",
+	"ip-dst|port":     "Network activity",
+	"ip-src|port":     "Network activity",
+	"domain":          "Network activity",
+	"domain|ip":       "Network activity",
+	"hostname":        "Network activity",
+	"url":             "Network activity",
+	"uri":             "Network activity",
+	"user-agent":      "Network activity",
+	"AS":              "Network activity",
+	"mac-address":     "Network activity",
+	"md5":             "Payload delivery",
+	"sha1":            "Payload delivery",
+	"sha256":          "Payload delivery",
+	"sha512":          "Payload delivery",
+	"ssdeep":          "Payload delivery",
+	"imphash":         "Payload delivery",
+	"filename":        "Payload delivery",
+	"filename|md5":    "Payload delivery",
+	"filename|sha1":   "Payload delivery",
+	"filename|sha256": "Payload delivery",
+	"email":           "Payload delivery",
+	"email-src":       "Payload delivery",
+	"email-dst":       "Payload delivery",
+	"email-subject":   "Payload delivery",
+	"regkey":          "Persistence mechanism",
+	"regkey|value":    "Persistence mechanism",
+	"mutex":           "Artifacts dropped",
+	"yara":            "Payload installation",
+	"vulnerability":   "External analysis",
+}
+
+// MispAssetObject is the MISP object an asset is exported as, and imported from
+const MispAssetObject = "device"
+
+// MispEventFile is the JSON document MISP exports and imports, an event wrapped in "Event"
+type MispEventFile struct {
+	Event MispEvent `json:"Event"`
+}
+
+// MispEvent represents a MISP event. Numeric MISP fields are strings, as in the MISP JSON format.
+type MispEvent struct {
+	UUID          string          `json:"uuid"`
+	Info          string          `json:"info"`
+	Date          string          `json:"date"`
+	ThreatLevelID string          `json:"threat_level_id"`
+	Analysis      string          `json:"analysis"`
+	Distribution  string          `json:"distribution"`
+	Published     bool            `json:"published"`
+	Tags          []MispTag       `json:"Tag,omitempty"`
+	Attributes    []MispAttribute `json:"Attribute"`
+	Objects       []MispObject    `json:"Object,omitempty"`
+	Galaxies      []MispGalaxy    `json:"Galaxy,omitempty"`
+}
+
+// MispTag is a MISP tag such as "tlp:amber"
+type MispTag struct {
+	Name string `json:"name"`
+}
+
+// MispAttribute is a MISP attribute, ObjectRelation is only set for attributes of an object
+type MispAttribute struct {
+	UUID           string    `json:"uuid"`
+	Type           string    `json:"type"`
+	Category       string    `json:"category"`
+	Value          string    `json:"value"`
+	Comment        string    `json:"comment"`
+	ToIDs          bool      `json:"to_ids"`
+	ObjectRelation string    `json:"object_relation,omitempty"`
+	Tags           []MispTag `json:"Tag,omitempty"`
+}
+
+// MispObject is a MISP object grouping attributes, such as a "device" or "file"
+type MispObject struct {
+	UUID         string                `json:"uuid"`
+	Name         string                `json:"name"`
+	MetaCategory string                `json:"meta-category"`
+	Comment      string                `json:"comment"`
+	Attributes   []MispAttribute       `json:"Attribute"`
+	References   []MispObjectReference `json:"ObjectReference,omitempty"`
+}
+
+// MispObjectReference links an object to another object or attribute
+type MispObjectReference struct {
+	ReferencedUUID   string `json:"referenced_uuid"`
+	RelationshipType string `json:"relationship_type"`
+}
+
+// MispGalaxy is a MISP galaxy such as the MITRE ATT&CK techniques with the clusters attached to the event
+type MispGalaxy struct {
+	Name     string              `json:"name"`
+	Type     string              `json:"type"`
+	Clusters []MispGalaxyCluster `json:"GalaxyCluster"`
+}
+
+// MispGalaxyCluster is a single galaxy cluster, TagName is the tag MISP uses for it
+type MispGalaxyCluster struct {
+	Value       string `json:"value"`
+	TagName     string `json:"tag_name"`
+	Description string `json:"description"`
+}
+
+// MispImportOptions controls ImportMispEvent
+type MispImportOptions struct {
+	// DefaultTlp is the TLP of attributes without tlp tag on the attribute or event, "amber" if empty
+	DefaultTlp string
+	// DefaultAssetType is used for device objects whose device-type is unknown to IRIS.
+	// Such objects are skipped if it is empty.
+	DefaultAssetType string
+	// AnalysisStatus is the analysis status of imported assets, "Unspecified" if empty
+	AnalysisStatus string
+	// SkipTags does not add the event tags and galaxy clusters to the case tags
+	SkipTags bool
+}
+
+// MispImportResult is the outcome of ImportMispEvent
+type MispImportResult struct {
+	Iocs   []Ioc
+	Assets []Asset
+	// Tags are the tags added to the case
+	Tags []string
+	// Skipped lists the attributes and objects that were not imported and why
+	Skipped []string
+}
+
+// NewMispEvent converts a case with its IOCs and assets to a MISP event. IOCs become attributes tagged with
+// their TLP and tags, assets become device objects referencing their linked IOCs and the case tags become
+// event tags. severity is the severity name of the case, it sets the threat level.
+//
+// Returns:
+// - MispEvent: The event.
+func NewMispEvent(c Case, severity string, iocs []Ioc, assets []Asset) MispEvent {
+	event := MispEvent{
+		UUID:          c.CaseUUID,
+		Info:          casePrefix.ReplaceAllString(c.CaseName, ""),
+		Date:          mispDate(c.CaseOpenDate),
+		ThreatLevelID: mispThreatLevel(severity),
+		Analysis:      "1",
+		Distribution:  "0",
+		Attributes:    []MispAttribute{},
+	}
+	if event.UUID == "" {
+		event.UUID = newUUID()
+	}
+	if c.CaseCloseDate != "" {
+		event.Analysis = "2"
+	}
+	for _, tag := range splitTags(c.CaseTags) {
+		event.Tags = append(event.Tags, MispTag{Name: tag})
+	}
+
+	attributes := make(map[int]string)
+	for _, ioc := range iocs {
+		attribute := MispAttribute{
+			UUID:    ioc.IocUUID,
+			Type:    ioc.IocType,
+			Value:   ioc.IocValue,
+			Comment: ioc.IocDescription,
+			ToIDs:   true,
+		}
+		if mispType, ok := MispTypes[ioc.IocType]; ok {
+			attribute.Type = mispType
+		}
+		attribute.Category = mispCategory(attribute.Type)
+		if attribute.UUID == "" {
+			attribute.UUID = newUUID()
+		}
+		if ioc.TlpName != "" {
+			attribute.Tags = append(attribute.Tags, MispTag{Name: "tlp:" + strings.ToLower(ioc.TlpName)})
+		}
+		for _, tag := range splitTags(ioc.IocTags) {
+			attribute.Tags = append(attribute.Tags, MispTag{Name: tag})
+		}
+		attributes[ioc.IocID] = attribute.UUID
+		event.Attributes = append(event.Attributes, attribute)
+	}
+
+	for _, asset := range assets {
+		object := MispObject{
+			UUID:         asset.AssetUUID,
+			Name:         MispAssetObject,
+			MetaCategory: "misc",
+			Comment:      asset.AssetDescription,
+		}
+		if object.UUID == "" {
+			object.UUID = newUUID()
+		}
+		addAttribute := func(relation, attributeType, value string) {
+			if value == "" {
+				return
+			}
+			object.Attributes = append(object.Attributes, MispAttribute{
+				UUID:           newUUID(),
+				Type:           attributeType,
+				Category:       "Other",
+				Value:          value,
+				ObjectRelation: relation,
+			})
+		}
+		addAttribute("name", "text", asset.AssetName)
+		addAttribute("device-type", "text", asset.AssetType)
+		addAttribute("description", "text", asset.AssetDescription)
+		addAttribute("ip-address", "ip-dst", asset.AssetIP)
+		addAttribute("domain", "domain", asset.AssetDomain)
+
+		for _, iocId := range asset.IocLinks {
+			if uuid, ok := attributes[iocId]; ok {
+				object.References = append(object.References, MispObjectReference{ReferencedUUID: uuid, RelationshipType: "related-to"})
+			}
+		}
+		event.Objects = append(event.Objects, object)
+	}
+
+	return event
+}
+
+// ExportMispEvent converts a case to a MISP event, see NewMispEvent.
+//
+// Example usage:
+//
+//	event, err := client.ExportMispEvent(42)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := goiris.WriteMispEventFile("case-42.misp.json", *event); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Returns:
+// - *MispEvent*: The event.
+// - error: An error if a request fails.
+func (client *APIClient) ExportMispEvent(caseId int) (*MispEvent, error) {
+	caseResponse, err := client.GetCase(caseId)
+	if err != nil {
+		return nil, err
+	}
+	iocs, err := client.ListIocs(caseId)
+	if err != nil {
+		return nil, err
+	}
+	assets, err := client.ListAssets(caseId)
+	if err != nil {
+		return nil, err
+	}
+
+	severity := ""
+	if caseResponse.Case.SeverityID != 0 {
+		if severity, err = client.Lookup().Name(LookupSeverity, caseResponse.Case.SeverityID); err != nil {
+			return nil, err
+		}
+	}
+	// the names are only filled by some IRIS versions, fall back to the reference data
+	for i, ioc := range iocs.Data.Iocs {
+		if ioc.IocType == "" {
+			if iocs.Data.Iocs[i].IocType, err = client.Lookup().Name(LookupIocType, ioc.IocTypeID); err != nil {
+				return nil, err
+			}
+		}
+		if ioc.TlpName == "" && ioc.IocTlpID != 0 {
+			if iocs.Data.Iocs[i].TlpName, err = client.Lookup().Name(LookupTlp, ioc.IocTlpID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i, asset := range assets.Data.Assets {
+		if asset.AssetType == "" && asset.AssetTypeID != 0 {
+			if assets.Data.Assets[i].AssetType, err = client.Lookup().Name(LookupAssetType, asset.AssetTypeID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	event := NewMispEvent(caseResponse.Case, severity, iocs.Data.Iocs, assets.Data.Assets)
+	return &event, nil
+}
+
+// LoadMispEventFile reads a MISP event JSON file, with or without the "Event" wrapper.
+func LoadMispEventFile(path string) (MispEvent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return MispEvent{}, err
+	}
+
+	var file MispEventFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return MispEvent{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if file.Event.UUID == "" && file.Event.Info == "" {
+		if err := json.Unmarshal(data, &file.Event); err != nil {
+			return MispEvent{}, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return file.Event, nil
+}
+
+// WriteMispEventFile writes event to path in the MISP JSON format
+func WriteMispEventFile(path string, event MispEvent) error {
+	data, err := json.MarshalIndent(MispEventFile{Event: event}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// ImportMispEvent adds the content of a MISP event to a case. Attributes, including the attributes of objects,
+// become IOCs of the same type, their tlp tag sets the TLP and their other tags become IOC tags. Device objects
+// become assets linked to the IOCs they reference. Event tags and galaxy clusters are added to the case tags.
+// Values and asset names the case already has are skipped, as are attribute types unknown to IRIS.
+//
+// Example usage:
+//
+//	event, err := goiris.LoadMispEventFile("event.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := client.ImportMispEvent(42, event, goiris.MispImportOptions{DefaultAssetType: "Windows - Computer"})
+//
+// Returns:
+// - *MispImportResult*: The created IOCs and assets, the added tags and the skipped entries.
+// - error: An error if a TLP or the analysis status is unknown to IRIS or a request fails.
+func (client *APIClient) ImportMispEvent(caseId int, event MispEvent, options MispImportOptions) (*MispImportResult, error) {
+	defaultTlp := options.DefaultTlp
+	if defaultTlp == "" {
+		defaultTlp = "amber"
+	}
+	if tlp := mispTlp(event.Tags); tlp != "" {
+		defaultTlp = tlp
+	}
+	analysisStatus := options.AnalysisStatus
+	if analysisStatus == "" {
+		analysisStatus = "Unspecified"
+	}
+
+	existingIocs, err := client.ListIocs(caseId)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]int)
+	for _, ioc := range existingIocs.Data.Iocs {
+		seen[strings.ToLower(ioc.IocValue)] = ioc.IocID
+	}
+	existingAssets, err := client.ListAssets(caseId)
+	if err != nil {
+		return nil, err
+	}
+	seenAssets := make(map[string]bool)
+	for _, asset := range existingAssets.Data.Assets {
+		seenAssets[strings.ToLower(asset.AssetName)] = true
+	}
+
+	result := &MispImportResult{}
+	iocIds := make(map[string]int)
+	addIoc := func(attribute MispAttribute) error {
+		if id, ok := seen[strings.ToLower(attribute.Value)]; ok {
+			iocIds[attribute.UUID] = id
+			result.Skipped = append(result.Skipped, fmt.Sprintf("attribute %s %q: already in the case", attribute.Type, attribute.Value))
+			return nil
+		}
+		typeId, err := client.Lookup().IocTypeID(attribute.Type)
+		if errors.Is(err, ErrLookupNotFound) {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("attribute %s %q: %v", attribute.Type, attribute.Value, err))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		tlp := mispTlp(attribute.Tags)
+		if tlp == "" {
+			tlp = defaultTlp
+		}
+		tlpId, err := client.Lookup().TlpID(tlp)
+		if errors.Is(err, ErrLookupNotFound) && tlp == "white" {
+			// TLP 2.0 renamed white to clear
+			tlpId, err = client.Lookup().TlpID("clear")
+		}
+		if err != nil {
+			return err
+		}
+
+		var tags []string
+		for _, tag := range attribute.Tags {
+			if !strings.HasPrefix(strings.ToLower(tag.Name), "tlp:") {
+				tags = append(tags, tag.Name)
+			}
+		}
+		created, err := client.AddIoc(caseId, IocRequest{
+			IocValue:       attribute.Value,
+			IocTypeID:      typeId,
+			IocTlpID:       tlpId,
+			IocDescription: attribute.Comment,
+			IocTags:        strings.Join(tags, ","),
+		})
+		if err != nil {
+			return fmt.Errorf("attribute %s %q: %w", attribute.Type, attribute.Value, err)
+		}
+		seen[strings.ToLower(attribute.Value)] = created.Ioc.IocID
+		iocIds[attribute.UUID] = created.Ioc.IocID
+		result.Iocs = append(result.Iocs, created.Ioc)
+		return nil
+	}
+
+	for _, attribute := range event.Attributes {
+		if err := addIoc(attribute); err != nil {
+			return nil, err
+		}
+	}
+	// device objects describe assets, the attributes of all other objects are indicators
+	for _, object := range event.Objects {
+		if object.Name == MispAssetObject {
+			continue
+		}
+		for _, attribute := range object.Attributes {
+			if err := addIoc(attribute); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, object := range event.Objects {
+		if object.Name != MispAssetObject {
+			continue
+		}
+		relations := make(map[string]string)
+		for _, attribute := range object.Attributes {
+			if _, ok := relations[attribute.ObjectRelation]; !ok {
+				relations[attribute.ObjectRelation] = attribute.Value
+			}
+		}
+		name := relations["name"]
+		if name == "" {
+			name = relations["hostname"]
+		}
+		if name == "" {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("object %s: device without name", object.UUID))
+			continue
+		}
+		if seenAssets[strings.ToLower(name)] {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("object %s %q: asset already in the case", object.UUID, name))
+			continue
+		}
+
+		assetTypeId, err := client.Lookup().AssetTypeID(relations["device-type"])
+		if errors.Is(err, ErrLookupNotFound) && options.DefaultAssetType != "" {
+			assetTypeId, err = client.Lookup().AssetTypeID(options.DefaultAssetType)
+		}
+		if errors.Is(err, ErrLookupNotFound) {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("object %s %q: %v", object.UUID, name, err))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		statusId, err := client.Lookup().AnalysisStatusID(analysisStatus)
+		if err != nil {
+			return nil, err
+		}
+
+		var links []int
+		for _, reference := range object.References {
+			if id, ok := iocIds[reference.ReferencedUUID]; ok {
+				links = append(links, id)
+			}
+		}
+		description := relations["description"]
+		if description == "" {
+			description = object.Comment
+		}
+		created, err := client.AddAsset(caseId, AssetRequest{
+			AssetName:        name,
+			AssetTypeID:      assetTypeId,
+			AnalysisStatusID: statusId,
+			AssetDescription: description,
+			AssetIP:          relations["ip-address"],
+			AssetDomain:      relations["domain"],
+			IocLinks:         links,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("object %s %q: %w", object.UUID, name, err)
+		}
+		seenAssets[strings.ToLower(name)] = true
+		result.Assets = append(result.Assets, created.Asset)
+	}
+
+	if options.SkipTags {
+		return result, nil
+	}
+	var tags []string
+	for _, tag := range event.Tags {
+		if !strings.HasPrefix(strings.ToLower(tag.Name), "tlp:") {
+			tags = append(tags, tag.Name)
+		}
+	}
+	for _, galaxy := range event.Galaxies {
+		for _, cluster := range galaxy.Clusters {
+			if cluster.TagName != "" {
+				tags = append(tags, cluster.TagName)
+			}
+		}
+	}
+	if len(tags) == 0 {
+		return result, nil
+	}
+
+	caseResponse, err := client.GetCase(caseId)
+	if err != nil {
+		return nil, err
+	}
+	caseTags := splitTags(caseResponse.Case.CaseTags)
+	known := make(map[string]bool)
+	for _, tag := range caseTags {
+		known[strings.ToLower(tag)] = true
+	}
+	for _, tag := range tags {
+		if !known[strings.ToLower(tag)] {
+			known[strings.ToLower(tag)] = true
+			caseTags = append(caseTags, tag)
+			result.Tags = append(result.Tags, tag)
+		}
+	}
+	if len(result.Tags) > 0 {
+		if _, err := client.UpdateCase(caseId, UpdateCaseRequest{CaseTags: strings.Join(caseTags, ",")}); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func mispCategory(attributeType string) string {
+	if category, ok := mispCategories[attributeType]; ok {
+		return category
+	}
+	return "Other"
+}
+
+// mispThreatLevel maps an IRIS severity to the MISP threat level: 1 high, 2 medium, 3 low, 4 undefined
+func mispThreatLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return "1"
+	case "medium":
+		return "2"
+	case "low", "informational":
+		return "3"
+	}
+	return "4"
+}
+
+// mispDate returns the date of a case in the YYYY-MM-DD format MISP expects, today if it cannot be parsed
+func mispDate(date string) string {
+	for _, layout := range []string{"2006-01-02", "01/02/2006"} {
+		if len(date) >= len(layout) {
+			if t, err := time.Parse(layout, date[:len(layout)]); err == nil {
+				return t.Format("2006-01-02")
+			}
+		}
+	}
+	return time.Now().Format("2006-01-02")
+}
+
+// mispTlp returns the TLP name of the first tlp tag
+func mispTlp(tags []MispTag) string {
+	for _, tag := range tags {
+		name := strings.ToLower(tag.Name)
+		if strings.HasPrefix(name, "tlp:") {
+			return strings.TrimPrefix(name, "tlp:")
+		}
+	}
+	return ""
+}
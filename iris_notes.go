@@ -0,0 +1,291 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// NoteDirectoriesResponse represents the response of the /case/notes/directories/filter endpoint
+type NoteDirectoriesResponse struct {
+	NoteDirectories []NoteDirectory `json:"data"`
+	ApiMeta
+}
+
+// NoteDirectoryAPIResponse represents the response of a single note directory api action
+type NoteDirectoryAPIResponse struct {
+	NoteDirectory NoteDirectory `json:"data"`
+	ApiMeta
+}
+
+// NoteAPIResponse represents the response of a single note api action
+type NoteAPIResponse struct {
+	Note Note `json:"data"`
+	ApiMeta
+}
+
+// NoteDirectory represents a directory of notes. The filter endpoint only returns the ID and title of the notes.
+type NoteDirectory struct {
+	ID             int             `json:"id"`
+	Name           string          `json:"name"`
+	ParentID       int             `json:"parent_id"`
+	Notes          []Note          `json:"notes"`
+	Subdirectories []NoteDirectory `json:"subdirectories"`
+}
+
+// Note represents a Markdown note of a case
+type Note struct {
+	NoteID           int              `json:"note_id"`
+	NoteUUID         string           `json:"note_uuid"`
+	NoteTitle        string           `json:"note_title"`
+	NoteContent      string           `json:"note_content"`
+	DirectoryID      int              `json:"directory_id"`
+	NoteCreationDate string           `json:"note_creationdate"`
+	NoteLastUpdate   string           `json:"note_lastupdate"`
+	CustomAttributes CustomAttributes `json:"custom_attributes"`
+}
+
+// UnmarshalJSON accepts the short {"id", "title"} form used inside directories as well as the full note
+func (note *Note) UnmarshalJSON(data []byte) error {
+	type plainNote Note
+	var short struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+		plainNote
+	}
+	if err := json.Unmarshal(data, &short); err != nil {
+		return err
+	}
+
+	*note = Note(short.plainNote)
+	if note.NoteID == 0 {
+		note.NoteID = short.ID
+	}
+	if note.NoteTitle == "" {
+		note.NoteTitle = short.Title
+	}
+	return nil
+}
+
+// NoteRequest represents a struct for adding or updating a note of a case
+type NoteRequest struct {
+	NoteTitle        string          `json:"note_title"`
+	NoteContent      string          `json:"note_content"`
+	DirectoryID      int             `json:"directory_id"`
+	CustomAttributes AttributeValues `json:"custom_attributes,omitempty"`
+}
+
+// NoteDirectoryRequest represents a struct for adding a note directory, ParentID 0 adds it at the root
+type NoteDirectoryRequest struct {
+	Name     string `json:"name"`
+	ParentID int    `json:"parent_id,omitempty"`
+}
+
+// ListNoteDirectories gets the note directory tree of a case from the /case/notes/directories/filter endpoint.
+//
+// Example usage:
+//
+//	directories, err := client.ListNoteDirectories(42)
+//	if err != nil {
+//	    log.Fatalf("Failed to list notes: %v", err)
+//	}
+//	for _, directory := range directories.NoteDirectories {
+//		for _, note := range directory.Notes {
+//			fmt.Println(directory.Name, note.NoteID, note.NoteTitle)
+//		}
+//	}
+//
+// Returns:
+// - *NoteDirectoriesResponse*: The response from the API containing the directories in the NoteDirectories field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListNoteDirectories(caseId int) (*NoteDirectoriesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/case/notes/directories/filter").
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var noteDirectoriesResponse NoteDirectoriesResponse
+	if err := json.NewDecoder(req.Body).Decode(&noteDirectoriesResponse); err != nil {
+		return nil, err
+	}
+
+	return &noteDirectoriesResponse, nil
+}
+
+// AddNoteDirectory adds a note directory to a case through the /case/notes/directories/add endpoint.
+//
+// Returns:
+// - *NoteDirectoryAPIResponse*: The response from the API containing the created directory in the NoteDirectory field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddNoteDirectory(caseId int, directory NoteDirectoryRequest) (*NoteDirectoryAPIResponse, error) {
+	jsondata, err := json.Marshal(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/case/notes/directories/add").
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var noteDirectoryResponse NoteDirectoryAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&noteDirectoryResponse); err != nil {
+		return nil, err
+	}
+
+	return &noteDirectoryResponse, nil
+}
+
+// GetNote returns a single note including its content from the /case/notes/<note-id> endpoint.
+//
+// Returns:
+// - *NoteAPIResponse*: The response from the API containing the note in the Note field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetNote(caseId int, noteId int) (*NoteAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/notes/%d", noteId)).
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var noteResponse NoteAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&noteResponse); err != nil {
+		return nil, err
+	}
+
+	return &noteResponse, nil
+}
+
+// AddNote adds a note to a case through the /case/notes/add endpoint.
+//
+// Returns:
+// - *NoteAPIResponse*: The response from the API containing the created note in the Note field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddNote(caseId int, note NoteRequest) (*NoteAPIResponse, error) {
+	jsondata, err := json.Marshal(note)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/case/notes/add").
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var noteResponse NoteAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&noteResponse); err != nil {
+		return nil, err
+	}
+
+	return &noteResponse, nil
+}
+
+// UpdateNote updates a note of a case through the /case/notes/update/<note-id> endpoint.
+//
+// Returns:
+// - *NoteAPIResponse*: The response from the API containing the updated note in the Note field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateNote(caseId int, noteId int, note NoteRequest) (*NoteAPIResponse, error) {
+	jsondata, err := json.Marshal(note)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/notes/update/%d", noteId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var noteResponse NoteAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&noteResponse); err != nil {
+		return nil, err
+	}
+
+	return &noteResponse, nil
+}
+
+// DeleteNote removes a note from a case using the /case/notes/delete/<note-id> endpoint.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) DeleteNote(caseId int, noteId int) error {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/notes/delete/%d", noteId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,453 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// AssetTypesResponse represents the response of the /manage/asset-type/list endpoint
+type AssetTypesResponse struct {
+	AssetTypes []AssetType `json:"data"`
+	ApiMeta
+}
+
+// IocTypesResponse represents the response of the /manage/ioc-types/list endpoint
+type IocTypesResponse struct {
+	IocTypes []IocType `json:"data"`
+	ApiMeta
+}
+
+// CaseClassificationsResponse represents the response of the /manage/case-classifications/list endpoint
+type CaseClassificationsResponse struct {
+	CaseClassifications []CaseClassification `json:"data"`
+	ApiMeta
+}
+
+// CaseStatesResponse represents the response of the /manage/case-states/list endpoint
+type CaseStatesResponse struct {
+	CaseStates []CaseState `json:"data"`
+	ApiMeta
+}
+
+// EvidenceTypesResponse represents the response of the /manage/evidence-types/list endpoint
+type EvidenceTypesResponse struct {
+	EvidenceTypes []EvidenceType `json:"data"`
+	ApiMeta
+}
+
+// EventCategoriesResponse represents the response of the /manage/event-categories/list endpoint
+type EventCategoriesResponse struct {
+	EventCategories []EventCategory `json:"data"`
+	ApiMeta
+}
+
+// AnalysisStatusesResponse represents the response of the /manage/analysis-status/list endpoint
+type AnalysisStatusesResponse struct {
+	AnalysisStatuses []AnalysisStatus `json:"data"`
+	ApiMeta
+}
+
+// TlpsResponse represents the response of the /manage/tlp/list endpoint
+type TlpsResponse struct {
+	Tlps []Tlp `json:"data"`
+	ApiMeta
+}
+
+// SeveritiesResponse represents the response of the /manage/severities/list endpoint
+type SeveritiesResponse struct {
+	Severities []Severity `json:"data"`
+	ApiMeta
+}
+
+// TaskStatusesResponse represents the response of the /manage/task-status/list endpoint
+type TaskStatusesResponse struct {
+	TaskStatuses []TaskStatus `json:"data"`
+	ApiMeta
+}
+
+// AssetType represents an asset type such as "Windows - Computer"
+type AssetType struct {
+	AssetID                 int    `json:"asset_id"`
+	AssetName               string `json:"asset_name"`
+	AssetDescription        string `json:"asset_description"`
+	AssetIconCompromised    string `json:"asset_icon_compromised"`
+	AssetIconNotCompromised string `json:"asset_icon_not_compromised"`
+}
+
+// IocType represents an IOC type such as "ip-dst" including its validation rule
+type IocType struct {
+	TypeID               int    `json:"type_id"`
+	TypeName             string `json:"type_name"`
+	TypeDescription      string `json:"type_description"`
+	TypeTaxonomy         string `json:"type_taxonomy"`
+	TypeValidationRegex  string `json:"type_validation_regex"`
+	TypeValidationExpect string `json:"type_validation_expect"`
+}
+
+// CaseClassification represents a case classification such as "malicious-code:ransomware"
+type CaseClassification struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	NameExpanded string `json:"name_expanded"`
+	Description  string `json:"description"`
+}
+
+// CaseState represents a case state such as "Open" or "Containment"
+type CaseState struct {
+	StateID          int    `json:"state_id"`
+	StateName        string `json:"state_name"`
+	StateDescription string `json:"state_description"`
+	Protected        bool   `json:"protected"`
+}
+
+// EvidenceType represents an evidence type such as "HDD image"
+type EvidenceType struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// EventCategory represents a timeline event category such as "Lateral Movement"
+type EventCategory struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// AnalysisStatus represents an asset analysis status such as "Started"
+type AnalysisStatus struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Tlp represents a traffic light protocol level such as "amber"
+type Tlp struct {
+	TlpID      int    `json:"tlp_id"`
+	TlpName    string `json:"tlp_name"`
+	TlpBsColor string `json:"tlp_bscolor"`
+}
+
+// Severity represents a severity such as "High"
+type Severity struct {
+	SeverityID          int    `json:"severity_id"`
+	SeverityName        string `json:"severity_name"`
+	SeverityDescription string `json:"severity_description"`
+}
+
+// TaskStatus represents a status of case and global tasks such as "In progress"
+type TaskStatus struct {
+	ID                int    `json:"id"`
+	StatusName        string `json:"status_name"`
+	StatusDescription string `json:"status_description"`
+	StatusBsColor     string `json:"status_bscolor"`
+}
+
+// ListAssetTypes gets all asset types from the /manage/asset-type/list endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *AssetTypesResponse*: The response from the API containing the asset types in the AssetTypes field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListAssetTypes() (*AssetTypesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/asset-type/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var response AssetTypesResponse
+	if err := json.NewDecoder(req.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListIocTypes gets all IOC types from the /manage/ioc-types/list endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *IocTypesResponse*: The response from the API containing the IOC types in the IocTypes field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListIocTypes() (*IocTypesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/ioc-types/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var response IocTypesResponse
+	if err := json.NewDecoder(req.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListCaseClassifications gets all case classifications from the /manage/case-classifications/list endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *CaseClassificationsResponse*: The response from the API containing the case classifications in the CaseClassifications field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListCaseClassifications() (*CaseClassificationsResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/case-classifications/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var response CaseClassificationsResponse
+	if err := json.NewDecoder(req.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListCaseStates gets all case states from the /manage/case-states/list endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *CaseStatesResponse*: The response from the API containing the case states in the CaseStates field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListCaseStates() (*CaseStatesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/case-states/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var response CaseStatesResponse
+	if err := json.NewDecoder(req.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListEvidenceTypes gets all evidence types from the /manage/evidence-types/list endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *EvidenceTypesResponse*: The response from the API containing the evidence types in the EvidenceTypes field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListEvidenceTypes() (*EvidenceTypesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/evidence-types/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var response EvidenceTypesResponse
+	if err := json.NewDecoder(req.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListEventCategories gets all event categories from the /manage/event-categories/list endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *EventCategoriesResponse*: The response from the API containing the event categories in the EventCategories field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListEventCategories() (*EventCategoriesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/event-categories/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var response EventCategoriesResponse
+	if err := json.NewDecoder(req.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListAnalysisStatuses gets all analysis statuses from the /manage/analysis-status/list endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *AnalysisStatusesResponse*: The response from the API containing the analysis statuses in the AnalysisStatuses field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListAnalysisStatuses() (*AnalysisStatusesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/analysis-status/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var response AnalysisStatusesResponse
+	if err := json.NewDecoder(req.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListTlps gets all TLPs from the /manage/tlp/list endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *TlpsResponse*: The response from the API containing the TLPs in the Tlps field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListTlps() (*TlpsResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/tlp/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var response TlpsResponse
+	if err := json.NewDecoder(req.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListSeverities gets all severities from the /manage/severities/list endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *SeveritiesResponse*: The response from the API containing the severities in the Severities field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListSeverities() (*SeveritiesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/severities/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var response SeveritiesResponse
+	if err := json.NewDecoder(req.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListTaskStatuses gets all task statuses from the /manage/task-status/list endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *TaskStatusesResponse*: The response from the API containing the task statuses in the TaskStatuses field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListTaskStatuses() (*TaskStatusesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/task-status/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var response TaskStatusesResponse
+	if err := json.NewDecoder(req.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,245 @@
+package goiris
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+)
+
+// Report types known by IRIS
+const (
+	ReportTypeInvestigation = 1
+	ReportTypeActivities    = 2
+)
+
+// ReportTemplatesResponse represents the response of the /manage/templates/list endpoint
+type ReportTemplatesResponse struct {
+	ReportTemplates []ReportTemplate `json:"data"`
+	ApiMeta
+}
+
+// ReportTemplateAPIResponse represents the response of a single report template api action
+type ReportTemplateAPIResponse struct {
+	ReportTemplate ReportTemplate `json:"data"`
+	ApiMeta
+}
+
+// ReportTemplate represents a DOCX or Markdown report template
+type ReportTemplate struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	NamingFormat string `json:"naming_format"`
+	DateCreated  string `json:"date_created"`
+	CreatedBy    string `json:"created_by"`
+	Code         string `json:"code"`
+	TypeName     string `json:"type_name"`
+}
+
+// AddReportTemplateRequest represents a struct for uploading a new report template.
+// NamingFormat may contain placeholders such as %customer%, %case_name% and %date%.
+type AddReportTemplateRequest struct {
+	Name         string
+	Description  string
+	NamingFormat string
+	LanguageID   int
+	ReportTypeID int
+	FileName     string
+	File         io.Reader
+}
+
+// ListReportTemplates gets a list of all report templates from the /manage/templates/list endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Returns:
+// - *ReportTemplatesResponse*: The response from the API containing the templates in the ReportTemplates field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListReportTemplates() (*ReportTemplatesResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/templates/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var templatesResponse ReportTemplatesResponse
+	if err := json.NewDecoder(req.Body).Decode(&templatesResponse); err != nil {
+		return nil, err
+	}
+
+	return &templatesResponse, nil
+}
+
+// AddReportTemplate uploads a report template through the /manage/templates/add endpoint.
+// If the request fails or the response cannot be decoded,
+// an error is returned.
+//
+// Example usage:
+//
+//	file, err := os.Open("incident_report.docx")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer file.Close()
+//
+//	template, err := client.AddReportTemplate(goiris.AddReportTemplateRequest{
+//		Name:         "Incident report",
+//		NamingFormat: "%customer%_%case_name%_%date%",
+//		LanguageID:   1,
+//		ReportTypeID: goiris.ReportTypeInvestigation,
+//		FileName:     "incident_report.docx",
+//		File:         file,
+//	})
+//
+// Returns:
+// - *ReportTemplateAPIResponse*: The response from the API containing the template in the ReportTemplate field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddReportTemplate(template AddReportTemplateRequest) (*ReportTemplateAPIResponse, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	fields := map[string]string{
+		"report_name":        template.Name,
+		"report_description": template.Description,
+		"report_name_format": template.NamingFormat,
+		"report_language":    strconv.Itoa(template.LanguageID),
+		"report_type":        strconv.Itoa(template.ReportTypeID),
+	}
+	for key, value := range fields {
+		if err := writer.WriteField(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	part, err := writer.CreateFormFile("file", template.FileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, template.File); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/manage/templates/add").
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", writer.FormDataContentType()).
+		SetBody(body.Bytes()).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var templateResponse ReportTemplateAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&templateResponse); err != nil {
+		return nil, err
+	}
+
+	return &templateResponse, nil
+}
+
+// DeleteReportTemplate removes a report template using the /manage/templates/delete/<id> endpoint.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) DeleteReportTemplate(templateId int) error {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/templates/delete/%d", templateId)).
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return nil
+}
+
+// GenerateCaseReport renders the report template templateId for the case caseId and streams the
+// resulting document into w. reportType selects between ReportTypeInvestigation and ReportTypeActivities
+// and has to match the type of the template.
+//
+// Example usage:
+//
+//	var report bytes.Buffer
+//	filename, err := client.GenerateCaseReport(42, 1, goiris.ReportTypeInvestigation, &report)
+//	if err != nil {
+//	    log.Fatalf("Failed to generate report: %v", err)
+//	}
+//	os.WriteFile(filename, report.Bytes(), 0o644)
+//
+// Returns:
+// - string: The base name of the file announced by IRIS in the Content-Disposition header, empty if there was none.
+// - error: An error if the request fails or the document cannot be written to w.
+func (client *APIClient) GenerateCaseReport(caseId int, templateId int, reportType int, w io.Writer) (string, error) {
+	var endpoint string
+	switch reportType {
+	case ReportTypeInvestigation:
+		endpoint = "/case/report/generate-investigation/%d"
+	case ReportTypeActivities:
+		endpoint = "/case/report/generate-activities/%d"
+	default:
+		return "", fmt.Errorf("unknown report type: %d", reportType)
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf(endpoint, templateId)).
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return "", err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var filename string
+	if _, params, err := mime.ParseMediaType(req.Header.Get("Content-Disposition")); err == nil {
+		if name := params["filename"]; name != "" {
+			// never trust a server supplied path
+			filename = filepath.Base(name)
+		}
+	}
+
+	if _, err := io.Copy(w, req.Body); err != nil {
+		return filename, err
+	}
+
+	return filename, nil
+}
@@ -0,0 +1,170 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// SearchType is the kind of objects searched by the /search endpoint
+type SearchType string
+
+const (
+	SearchIocs  SearchType = "ioc"
+	SearchNotes SearchType = "notes"
+)
+
+// SearchRequest represents the body of the /search endpoint. SearchValue is an SQL LIKE pattern.
+type SearchRequest struct {
+	SearchValue string     `json:"search_value"`
+	SearchType  SearchType `json:"search_type"`
+}
+
+// IocSearchResponse represents the response of an IOC search
+type IocSearchResponse struct {
+	Hits []IocSearchHit `json:"data"`
+	ApiMeta
+}
+
+// IocSearchHit is an IOC matching a search with its case
+type IocSearchHit struct {
+	IocValue       string `json:"ioc_name"`
+	IocDescription string `json:"ioc_description"`
+	IocMisp        string `json:"ioc_misp"`
+	TypeName       string `json:"type_name"`
+	TlpName        string `json:"tlp_name"`
+	CaseID         int    `json:"case_id"`
+	CaseName       string `json:"case_name"`
+	CustomerName   string `json:"customer_name"`
+}
+
+// NoteSearchResponse represents the response of a notes search
+type NoteSearchResponse struct {
+	Hits []NoteSearchHit `json:"data"`
+	ApiMeta
+}
+
+// NoteSearchHit is a note whose content matches a search
+type NoteSearchHit struct {
+	NoteID       int    `json:"note_id"`
+	NoteTitle    string `json:"note_title"`
+	CaseID       int    `json:"case_id"`
+	CaseName     string `json:"case_name"`
+	CustomerName string `json:"client_name"`
+}
+
+// SearchCase is a case returned by SeenIOC
+type SearchCase struct {
+	CaseID       int    `json:"case_id"`
+	CaseName     string `json:"case_name"`
+	CustomerName string `json:"customer_name"`
+}
+
+// SearchIocs searches IOC values of all cases with the /search endpoint. The pattern may use the
+// wildcard *, or the SQL wildcards % and _. A ? is matched literally, e.g. in URLs.
+//
+// Example usage:
+//
+//	hits, err := client.SearchIocs("*.evil.example")
+//
+// Returns:
+// - *IocSearchResponse*: The response from the API containing the matching IOCs in the Hits field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) SearchIocs(pattern string) (*IocSearchResponse, error) {
+	var iocSearchResponse IocSearchResponse
+	if err := client.search(SearchIocs, searchPattern(pattern), &iocSearchResponse); err != nil {
+		return nil, err
+	}
+	return &iocSearchResponse, nil
+}
+
+// SearchNotes searches the content of the notes of all cases with the /search endpoint. The pattern
+// may use the wildcard *, or the SQL wildcards % and _; IRIS does not add wildcards itself. A ? is
+// matched literally.
+//
+// Example usage:
+//
+//	hits, err := client.SearchNotes("*mimikatz*")
+//
+// Returns:
+// - *NoteSearchResponse*: The response from the API containing the matching notes in the Hits field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) SearchNotes(pattern string) (*NoteSearchResponse, error) {
+	var noteSearchResponse NoteSearchResponse
+	if err := client.search(SearchNotes, searchPattern(pattern), &noteSearchResponse); err != nil {
+		return nil, err
+	}
+	return &noteSearchResponse, nil
+}
+
+// SeenIOC returns every case containing an IOC with exactly this value. Matching is case-sensitive, as
+// the LIKE of PostgreSQL behind IRIS is: a hash stored in upper case is not found by its lower case value.
+//
+// Example usage:
+//
+//	cases, err := client.SeenIOC("44d88612fea8a8f36de82e1278abb02f")
+//	for _, c := range cases {
+//		fmt.Printf("seen in #%d %s (%s)\n", c.CaseID, c.CaseName, c.CustomerName)
+//	}
+//
+// Returns:
+// - []SearchCase: The cases sorted by ID, empty if the value was never seen.
+// - error: An error if the search fails.
+func (client *APIClient) SeenIOC(value string) ([]SearchCase, error) {
+	// escape the SQL wildcards so the value matches literally. This assumes backslash is the LIKE escape
+	// character, the PostgreSQL default without an ESCAPE clause, and IRIS gives none.
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+	var response IocSearchResponse
+	if err := client.search(SearchIocs, escaped, &response); err != nil {
+		return nil, err
+	}
+
+	seen := map[int]bool{}
+	cases := []SearchCase{}
+	for _, hit := range response.Hits {
+		if hit.IocValue != value || seen[hit.CaseID] {
+			continue
+		}
+		seen[hit.CaseID] = true
+		cases = append(cases, SearchCase{CaseID: hit.CaseID, CaseName: hit.CaseName, CustomerName: hit.CustomerName})
+	}
+	sort.Slice(cases, func(i, j int) bool {
+		return cases[i].CaseID < cases[j].CaseID
+	})
+
+	return cases, nil
+}
+
+// search posts a LIKE pattern to /search and decodes into response
+func (client *APIClient) search(searchType SearchType, value string, response interface{}) error {
+	jsondata, err := json.Marshal(SearchRequest{SearchValue: value, SearchType: searchType})
+	if err != nil {
+		return err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/search").
+		SetMethod(http.MethodPost).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return json.NewDecoder(req.Body).Decode(response)
+}
+
+// searchPattern converts the wildcard * to the SQL LIKE wildcard %. ? is kept, IOCs such as URLs contain it.
+func searchPattern(pattern string) string {
+	return strings.ReplaceAll(pattern, "*", "%")
+}
@@ -0,0 +1,623 @@
+package goiris
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StixTimeFormat is the timestamp layout of STIX 2.1
+const StixTimeFormat = "2006-01-02T15:04:05.000Z"
+
+// TLP 1.0 marking definitions predefined by STIX 2.1
+const (
+	StixTlpWhite = "marking-definition--613f2e26-407d-48c7-9eca-b8e91df99dc9"
+	StixTlpGreen = "marking-definition--34098fce-860f-48ae-8e50-ebd3cc5e41da"
+	StixTlpAmber = "marking-definition--f88d31f6-486f-44da-b317-01333bde0b82"
+	StixTlpRed   = "marking-definition--5e57c739-391a-4eb3-b6be-7d15ca92d5ed"
+)
+
+// stixTlps maps the marking definitions to TLP names, TLP 2.0 as used by IRIS calls white "clear"
+var stixTlps = map[string]string{
+	StixTlpWhite: "white",
+	StixTlpGreen: "green",
+	StixTlpAmber: "amber",
+	StixTlpRed:   "red",
+}
+
+// stixNamespace is the UUIDv5 namespace STIX 2.1 defines for deterministic observable IDs
+var stixNamespace = [16]byte{0x00, 0xab, 0xed, 0xb4, 0xaa, 0x42, 0x46, 0x6c, 0x9c, 0x01, 0xfe, 0xd2, 0x33, 0x15, 0xa9, 0xb7}
+
+// StixPatterns maps IRIS IOC types to the STIX object path used in indicator patterns.
+// Composite types such as "filename|sha256" are exported as one pattern joined with AND.
+var StixPatterns = map[string]string{
+	"ip-src":      "ipv4-addr:value",
+	"ip-dst":      "ipv4-addr:value",
+	"ip-any":      "ipv4-addr:value",
+	"domain":      "domain-name:value",
+	"hostname":    "domain-name:value",
+	"url":         "url:value",
+	"email":       "email-addr:value",
+	"email-src":   "email-addr:value",
+	"email-dst":   "email-addr:value",
+	"md5":         "file:hashes.MD5",
+	"sha1":        "file:hashes.'SHA-1'",
+	"sha256":      "file:hashes.'SHA-256'",
+	"sha512":      "file:hashes.'SHA-512'",
+	"filename":    "file:name",
+	"mac-address": "mac-addr:value",
+	"regkey":      "windows-registry-key:key",
+}
+
+// StixIocTypes maps STIX object paths to the IRIS IOC type created on import, see StixImportOptions
+var StixIocTypes = map[string]string{
+	"ipv4-addr:value":          "ip-any",
+	"ipv6-addr:value":          "ip-any",
+	"domain-name:value":        "domain",
+	"url:value":                "url",
+	"email-addr:value":         "email",
+	"file:hashes.MD5":          "md5",
+	"file:hashes.'SHA-1'":      "sha1",
+	"file:hashes.'SHA-256'":    "sha256",
+	"file:hashes.'SHA-512'":    "sha512",
+	"file:name":                "filename",
+	"mac-addr:value":           "mac-address",
+	"windows-registry-key:key": "regkey",
+}
+
+// stixHashes are the hash names of STIX file objects
+var stixHashes = []string{"MD5", "SHA-1", "SHA-256", "SHA-512"}
+
+// StixBundle is a STIX 2.1 bundle
+type StixBundle struct {
+	Type    string       `json:"type"`
+	ID      string       `json:"id"`
+	Objects []StixObject `json:"objects"`
+}
+
+// StixObject holds the properties of the STIX 2.1 objects used for IOCs: indicators, observables,
+// infrastructure, relationships and marking definitions. Unused properties are omitted.
+type StixObject struct {
+	Type              string   `json:"type"`
+	SpecVersion       string   `json:"spec_version,omitempty"`
+	ID                string   `json:"id"`
+	Created           string   `json:"created,omitempty"`
+	Modified          string   `json:"modified,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	Description       string   `json:"description,omitempty"`
+	Labels            []string `json:"labels,omitempty"`
+	ObjectMarkingRefs []string `json:"object_marking_refs,omitempty"`
+
+	IndicatorTypes []string `json:"indicator_types,omitempty"`
+	Pattern        string   `json:"pattern,omitempty"`
+	PatternType    string   `json:"pattern_type,omitempty"`
+	ValidFrom      string   `json:"valid_from,omitempty"`
+
+	InfrastructureTypes []string `json:"infrastructure_types,omitempty"`
+
+	RelationshipType string `json:"relationship_type,omitempty"`
+	SourceRef        string `json:"source_ref,omitempty"`
+	TargetRef        string `json:"target_ref,omitempty"`
+
+	Value  string            `json:"value,omitempty"`
+	Key    string            `json:"key,omitempty"`
+	Hashes map[string]string `json:"hashes,omitempty"`
+
+	DefinitionType string            `json:"definition_type,omitempty"`
+	Definition     map[string]string `json:"definition,omitempty"`
+}
+
+// StixImportOptions controls ImportStixBundle
+type StixImportOptions struct {
+	// IocTypes overrides entries of StixIocTypes, e.g. {"ipv4-addr:value": "ip-dst"}
+	IocTypes map[string]string
+	// DefaultTlp is the TLP of objects without TLP marking, "amber" if empty
+	DefaultTlp string
+}
+
+// StixImportResult is the outcome of ImportStixBundle
+type StixImportResult struct {
+	Created []Ioc
+	// Skipped lists the objects that were not imported and why
+	Skipped []string
+}
+
+// NewStixBundle converts IOCs and assets of a case to a STIX 2.1 bundle. Every IOC becomes an indicator
+// with the observable it is based on, every asset an infrastructure object related to its linked IOCs.
+// The TLP of the IOCs is mapped to the STIX TLP marking definitions.
+//
+// Returns:
+// - *StixBundle*: The bundle.
+// - []string: The IOCs that were skipped because their type has no STIX pattern, see StixPatterns.
+func NewStixBundle(iocs []Ioc, assets []Asset) (*StixBundle, []string) {
+	now := time.Now().UTC().Format(StixTimeFormat)
+	bundle := &StixBundle{Type: "bundle", ID: "bundle--" + newUUID()}
+	var skipped []string
+
+	markings := make(map[string]bool)
+	indicators := make(map[int]string)
+	var objects []StixObject
+	for _, ioc := range iocs {
+		pattern, observable, ok := stixPattern(ioc.IocType, ioc.IocValue)
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("ioc %d %q: no STIX pattern for type %q", ioc.IocID, ioc.IocValue, ioc.IocType))
+			continue
+		}
+
+		id := "indicator--" + ioc.IocUUID
+		if ioc.IocUUID == "" {
+			id = "indicator--" + newUUID()
+		}
+		indicators[ioc.IocID] = id
+
+		var markingRefs []string
+		if marking := stixTlpMarking(ioc.TlpName); marking != "" {
+			markingRefs = []string{marking}
+			markings[marking] = true
+		}
+
+		objects = append(objects, StixObject{
+			Type:              "indicator",
+			SpecVersion:       "2.1",
+			ID:                id,
+			Created:           now,
+			Modified:          now,
+			Name:              ioc.IocValue,
+			Description:       ioc.IocDescription,
+			Labels:            splitTags(ioc.IocTags),
+			ObjectMarkingRefs: markingRefs,
+			IndicatorTypes:    []string{"malicious-activity"},
+			Pattern:           pattern,
+			PatternType:       "stix",
+			ValidFrom:         now,
+		})
+
+		observable.ObjectMarkingRefs = markingRefs
+		objects = append(objects, observable, stixRelationship(now, id, "based-on", observable.ID))
+	}
+
+	for _, asset := range assets {
+		id := "infrastructure--" + asset.AssetUUID
+		if asset.AssetUUID == "" {
+			id = "infrastructure--" + newUUID()
+		}
+		objects = append(objects, StixObject{
+			Type:                "infrastructure",
+			SpecVersion:         "2.1",
+			ID:                  id,
+			Created:             now,
+			Modified:            now,
+			Name:                asset.AssetName,
+			Description:         asset.AssetDescription,
+			Labels:              splitTags(asset.AssetTags),
+			InfrastructureTypes: []string{"unknown"},
+		})
+		for _, iocId := range asset.IocLinks {
+			if indicator, ok := indicators[iocId]; ok {
+				objects = append(objects, stixRelationship(now, indicator, "related-to", id))
+			}
+		}
+	}
+
+	// the predefined marking definitions go first, so consumers know them before they are referenced
+	for _, marking := range sortedKeys(markings) {
+		bundle.Objects = append(bundle.Objects, StixObject{
+			Type:           "marking-definition",
+			SpecVersion:    "2.1",
+			ID:             marking,
+			Created:        "2017-01-20T00:00:00.000Z",
+			Name:           "TLP:" + strings.ToUpper(stixTlps[marking]),
+			DefinitionType: "tlp",
+			Definition:     map[string]string{"tlp": stixTlps[marking]},
+		})
+	}
+	bundle.Objects = append(bundle.Objects, objects...)
+
+	return bundle, skipped
+}
+
+// ExportStixBundle converts the IOCs and assets of a case to a STIX 2.1 bundle, see NewStixBundle.
+//
+// Example usage:
+//
+//	bundle, skipped, err := client.ExportStixBundle(42)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	data, _ := json.MarshalIndent(bundle, "", "  ")
+//	os.WriteFile("case-42.stix.json", data, 0o644)
+//
+// Returns:
+// - *StixBundle*: The bundle.
+// - []string: The IOCs that were skipped because their type has no STIX pattern.
+// - error: An error if a request fails.
+func (client *APIClient) ExportStixBundle(caseId int) (*StixBundle, []string, error) {
+	iocs, err := client.ListIocs(caseId)
+	if err != nil {
+		return nil, nil, err
+	}
+	assets, err := client.ListAssets(caseId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the names are only filled by some IRIS versions, fall back to the reference data
+	for i, ioc := range iocs.Data.Iocs {
+		if ioc.IocType == "" {
+			if iocs.Data.Iocs[i].IocType, err = client.Lookup().Name(LookupIocType, ioc.IocTypeID); err != nil {
+				return nil, nil, err
+			}
+		}
+		if ioc.TlpName == "" && ioc.IocTlpID != 0 {
+			if iocs.Data.Iocs[i].TlpName, err = client.Lookup().Name(LookupTlp, ioc.IocTlpID); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	bundle, skipped := NewStixBundle(iocs.Data.Iocs, assets.Data.Assets)
+	return bundle, skipped, nil
+}
+
+// ParseStixBundle decodes a STIX 2.1 bundle.
+//
+// Returns:
+// - *StixBundle*: The bundle.
+// - error: An error if r is not a STIX bundle.
+func ParseStixBundle(r io.Reader) (*StixBundle, error) {
+	var bundle StixBundle
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+		return nil, err
+	}
+	if bundle.Type != "bundle" {
+		return nil, fmt.Errorf("not a STIX bundle: type %q", bundle.Type)
+	}
+	return &bundle, nil
+}
+
+// ImportStixBundle creates IOCs in a case from the indicators and observables of a STIX 2.1 bundle.
+// Every comparison of an indicator pattern with a known object path becomes one IOC, see StixIocTypes.
+// Observables are only imported on their own if no indicator is based on them. Values the case already has
+// are skipped. TLP marking definitions are mapped to the TLPs of IRIS, labels become IOC tags.
+//
+// Example usage:
+//
+//	file, _ := os.Open("partner.stix.json")
+//	bundle, err := goiris.ParseStixBundle(file)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := client.ImportStixBundle(42, bundle, goiris.StixImportOptions{DefaultTlp: "green"})
+//
+// Returns:
+// - *StixImportResult*: The created IOCs and the skipped objects.
+// - error: An error if a TLP is unknown to IRIS or a request fails. Objects with an IOC type unknown to IRIS are skipped.
+func (client *APIClient) ImportStixBundle(caseId int, bundle *StixBundle, options StixImportOptions) (*StixImportResult, error) {
+	iocTypes := make(map[string]string)
+	for path, iocType := range StixIocTypes {
+		iocTypes[path] = iocType
+	}
+	for path, iocType := range options.IocTypes {
+		iocTypes[normalizeStixPath(path)] = iocType
+	}
+	defaultTlp := options.DefaultTlp
+	if defaultTlp == "" {
+		defaultTlp = "amber"
+	}
+
+	existing, err := client.ListIocs(caseId)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, ioc := range existing.Data.Iocs {
+		seen[strings.ToLower(ioc.IocValue)] = true
+	}
+
+	markings := make(map[string]string)
+	basedOn := make(map[string]bool)
+	for _, object := range bundle.Objects {
+		switch object.Type {
+		case "marking-definition":
+			if tlp, ok := object.Definition["tlp"]; ok {
+				markings[object.ID] = tlp
+			}
+		case "relationship":
+			if object.RelationshipType == "based-on" && strings.HasPrefix(object.SourceRef, "indicator--") {
+				basedOn[object.TargetRef] = true
+			}
+		}
+	}
+	for id, tlp := range stixTlps {
+		markings[id] = tlp
+	}
+
+	result := &StixImportResult{}
+	add := func(object StixObject, path, value string) error {
+		key := strings.ToLower(value)
+		if seen[key] {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s %q: already in the case", object.ID, value))
+			return nil
+		}
+		iocType, ok := iocTypes[path]
+		if !ok {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s %q: no IOC type for %s", object.ID, value, path))
+			return nil
+		}
+
+		typeId, err := client.Lookup().IocTypeID(iocType)
+		if errors.Is(err, ErrLookupNotFound) {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s %q: %v", object.ID, value, err))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		tlp := stixTlpName(object.ObjectMarkingRefs, markings, defaultTlp)
+		tlpId, err := client.Lookup().TlpID(tlp)
+		if errors.Is(err, ErrLookupNotFound) && tlp == "white" {
+			// TLP 2.0 renamed white to clear
+			tlpId, err = client.Lookup().TlpID("clear")
+		}
+		if err != nil {
+			return err
+		}
+
+		created, err := client.AddIoc(caseId, IocRequest{
+			IocValue:       value,
+			IocTypeID:      typeId,
+			IocTlpID:       tlpId,
+			IocDescription: object.Description,
+			IocTags:        strings.Join(object.Labels, ","),
+		})
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", object.ID, value, err)
+		}
+		seen[key] = true
+		result.Created = append(result.Created, created.Ioc)
+		return nil
+	}
+
+	for _, object := range bundle.Objects {
+		if object.Type != "indicator" {
+			continue
+		}
+		if object.PatternType != "" && object.PatternType != "stix" {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: pattern type %q", object.ID, object.PatternType))
+			continue
+		}
+		comparisons := parseStixPattern(object.Pattern)
+		if len(comparisons) == 0 {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: no comparison in pattern %q", object.ID, object.Pattern))
+		}
+		for _, comparison := range comparisons {
+			if err := add(object, comparison[0], comparison[1]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, object := range bundle.Objects {
+		if basedOn[object.ID] {
+			continue
+		}
+		for _, property := range stixObservableValues(object) {
+			if err := add(object, property[0], property[1]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// stixPattern returns the indicator pattern and the observable of an IOC
+func stixPattern(iocType, value string) (string, StixObject, bool) {
+	types := strings.Split(iocType, "|")
+	values := strings.Split(value, "|")
+	if len(types) != len(values) {
+		return "", StixObject{}, false
+	}
+
+	observable := StixObject{SpecVersion: "2.1"}
+	var comparisons []string
+	for i, t := range types {
+		path, ok := StixPatterns[strings.ToLower(t)]
+		if !ok {
+			return "", StixObject{}, false
+		}
+		if strings.HasPrefix(path, "ipv4-addr:") && strings.Contains(values[i], ":") {
+			path = "ipv6-addr:value"
+		}
+
+		objectType, property, _ := strings.Cut(path, ":")
+		if observable.Type != "" && observable.Type != objectType {
+			return "", StixObject{}, false
+		}
+		observable.Type = objectType
+		switch {
+		case property == "value":
+			observable.Value = values[i]
+		case property == "key":
+			observable.Key = values[i]
+		case property == "name":
+			observable.Name = values[i]
+		case strings.HasPrefix(property, "hashes."):
+			if observable.Hashes == nil {
+				observable.Hashes = make(map[string]string)
+			}
+			observable.Hashes[strings.Trim(strings.TrimPrefix(property, "hashes."), "'")] = values[i]
+		}
+
+		escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(values[i])
+		comparisons = append(comparisons, fmt.Sprintf("%s = '%s'", path, escaped))
+	}
+
+	observable.ID = observable.Type + "--" + stixObservableUUID(observable)
+	return "[" + strings.Join(comparisons, " AND ") + "]", observable, true
+}
+
+// stixComparison matches one comparison of a STIX pattern such as file:hashes.'SHA-256' = '...'
+var stixComparison = regexp.MustCompile(`([a-z0-9-]+:[A-Za-z0-9_.'-]+)\s*=\s*'((?:[^'\\]|\\.)*)'`)
+
+// parseStixPattern returns the normalized object path and the value of every equality comparison
+func parseStixPattern(pattern string) [][2]string {
+	var comparisons [][2]string
+	for _, match := range stixComparison.FindAllStringSubmatch(pattern, -1) {
+		value := strings.NewReplacer(`\'`, `'`, `\\`, `\`).Replace(match[2])
+		comparisons = append(comparisons, [2]string{normalizeStixPath(match[1]), value})
+	}
+	return comparisons
+}
+
+// normalizeStixPath writes hash names the way StixIocTypes does, so file:hashes.sha256 matches file:hashes.'SHA-256'
+func normalizeStixPath(path string) string {
+	prefix, hash, ok := strings.Cut(path, ":hashes.")
+	if !ok {
+		return path
+	}
+	name := strings.ToUpper(strings.ReplaceAll(strings.Trim(hash, "'"), "-", ""))
+	for _, known := range stixHashes {
+		if strings.ReplaceAll(known, "-", "") == name {
+			if strings.Contains(known, "-") {
+				return prefix + ":hashes.'" + known + "'"
+			}
+			return prefix + ":hashes." + known
+		}
+	}
+	return path
+}
+
+// stixObservableValues returns the object path and value of the properties of an observable
+func stixObservableValues(object StixObject) [][2]string {
+	var values [][2]string
+	switch object.Type {
+	case "ipv4-addr", "ipv6-addr", "domain-name", "url", "email-addr", "mac-addr":
+		if object.Value != "" {
+			values = append(values, [2]string{object.Type + ":value", object.Value})
+		}
+	case "windows-registry-key":
+		if object.Key != "" {
+			values = append(values, [2]string{object.Type + ":key", object.Key})
+		}
+	case "file":
+		if object.Name != "" {
+			values = append(values, [2]string{"file:name", object.Name})
+		}
+		hashes := make([]string, 0, len(object.Hashes))
+		for hash := range object.Hashes {
+			hashes = append(hashes, hash)
+		}
+		sort.Strings(hashes)
+		for _, hash := range hashes {
+			values = append(values, [2]string{normalizeStixPath("file:hashes." + hash), object.Hashes[hash]})
+		}
+	}
+	return values
+}
+
+// stixTlpMarking returns the marking definition of an IRIS TLP name
+func stixTlpMarking(tlp string) string {
+	switch strings.ToLower(strings.TrimSpace(tlp)) {
+	case "white", "clear":
+		return StixTlpWhite
+	case "green":
+		return StixTlpGreen
+	case "amber", "amber+strict":
+		return StixTlpAmber
+	case "red":
+		return StixTlpRed
+	}
+	return ""
+}
+
+// stixTlpName returns the most restrictive TLP of the marking references
+func stixTlpName(refs []string, markings map[string]string, fallback string) string {
+	order := []string{"white", "clear", "green", "amber", "red"}
+	best := -1
+	for _, ref := range refs {
+		tlp := strings.ToLower(markings[ref])
+		for i, name := range order {
+			if name == tlp && i > best {
+				best = i
+			}
+		}
+	}
+	if best < 0 {
+		return fallback
+	}
+	return order[best]
+}
+
+func stixRelationship(now, source, relationshipType, target string) StixObject {
+	return StixObject{
+		Type:             "relationship",
+		SpecVersion:      "2.1",
+		ID:               "relationship--" + uuid5(stixNamespace, source+"|"+relationshipType+"|"+target),
+		Created:          now,
+		Modified:         now,
+		RelationshipType: relationshipType,
+		SourceRef:        source,
+		TargetRef:        target,
+	}
+}
+
+// stixObservableUUID returns the deterministic ID STIX 2.1 defines for observables,
+// a UUIDv5 of the canonical JSON of the ID contributing properties
+func stixObservableUUID(observable StixObject) string {
+	contributing := make(map[string]interface{})
+	switch {
+	case observable.Value != "":
+		contributing["value"] = observable.Value
+	case observable.Key != "":
+		contributing["key"] = observable.Key
+	}
+	if observable.Name != "" {
+		contributing["name"] = observable.Name
+	}
+	if len(observable.Hashes) > 0 {
+		contributing["hashes"] = observable.Hashes
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(contributing)
+	return uuid5(stixNamespace, strings.TrimSuffix(buf.String(), "\n"))
+}
+
+func uuid5(namespace [16]byte, name string) string {
+	hash := sha1.New()
+	hash.Write(namespace[:])
+	hash.Write([]byte(name))
+	var id [16]byte
+	copy(id[:], hash.Sum(nil))
+	id[6] = id[6]&0x0f | 0x50
+	id[8] = id[8]&0x3f | 0x80
+	return formatUUID(id)
+}
+
+func newUUID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return formatUUID(id)
+}
+
+func formatUUID(id [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
+
+// splitTags splits the comma separated tags of IRIS
+func splitTags(tags string) []string {
+	return splitList(tags, ",")
+}
@@ -0,0 +1,87 @@
+package goiris
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStixPattern(t *testing.T) {
+	tests := []struct {
+		name       string
+		iocType    string
+		value      string
+		pattern    string
+		observable string
+		ok         bool
+	}{
+		{"ipv4", "ip-dst", "198.51.100.3", "[ipv4-addr:value = '198.51.100.3']", "ipv4-addr--28bb3599-77cd-5a82-a950-b5bc3caf07c4", true},
+		{"ipv6 in an ip type", "ip-src", "2001:db8::1", "[ipv6-addr:value = '2001:db8::1']", "", true},
+		{"type is case insensitive", "SHA256", "abc", "[file:hashes.'SHA-256' = 'abc']", "", true},
+		{"composite", "filename|sha256", "a.exe|abc", "[file:name = 'a.exe' AND file:hashes.'SHA-256' = 'abc']", "file--5a2b3f76-abbe-5a4e-8408-8210ded802e8", true},
+		{"quote and backslash escaped", "regkey", `HKLM\Software\It's`, `[windows-registry-key:key = 'HKLM\\Software\\It\'s']`, "", true},
+		{"html characters not escaped in the id", "url", "http://x/?a=<b>&c", "[url:value = 'http://x/?a=<b>&c']", "url--00e4b6d5-80ab-5bff-94a4-866698442c5c", true},
+		{"unknown type", "yara", "rule x {}", "", "", false},
+		{"composite value count mismatch", "filename|sha256", "a.exe", "", "", false},
+		{"composite of different objects", "ip-dst|domain", "198.51.100.3|example.com", "", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pattern, observable, ok := stixPattern(test.iocType, test.value)
+			if ok != test.ok {
+				t.Fatalf("ok = %v, want %v", ok, test.ok)
+			}
+			if pattern != test.pattern {
+				t.Errorf("pattern = %q, want %q", pattern, test.pattern)
+			}
+			if test.observable != "" && observable.ID != test.observable {
+				t.Errorf("observable id = %q, want %q", observable.ID, test.observable)
+			}
+		})
+	}
+}
+
+func TestParseStixPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		want    [][2]string
+	}{
+		{"single", "[domain-name:value = 'example.com']", [][2]string{{"domain-name:value", "example.com"}}},
+		{"no spaces", "[url:value='http://x/']", [][2]string{{"url:value", "http://x/"}}},
+		{"escaped", `[windows-registry-key:key = 'HKLM\\Run\\It\'s']`, [][2]string{{"windows-registry-key:key", `HKLM\Run\It's`}}},
+		{"hash name normalized", "[file:hashes.sha256 = 'abc' AND file:hashes.'sha-1' = 'def']", [][2]string{{"file:hashes.'SHA-256'", "abc"}, {"file:hashes.'SHA-1'", "def"}}},
+		{"md5 stays unquoted", "[file:hashes.'MD5' = 'abc']", [][2]string{{"file:hashes.MD5", "abc"}}},
+		{"unknown hash kept", "[file:hashes.TLSH = 'abc']", [][2]string{{"file:hashes.TLSH", "abc"}}},
+		{"or and observation expressions", "[ipv4-addr:value = '10.0.0.1'] OR [ipv4-addr:value = '10.0.0.2']", [][2]string{{"ipv4-addr:value", "10.0.0.1"}, {"ipv4-addr:value", "10.0.0.2"}}},
+		{"other operators ignored", "[ipv4-addr:value ISSUBSET '10.0.0.0/8']", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := parseStixPattern(test.pattern); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parseStixPattern(%q) = %q, want %q", test.pattern, got, test.want)
+			}
+		})
+	}
+}
+
+func TestStixPatternRoundTrip(t *testing.T) {
+	for _, value := range []string{`plain`, `it's`, `C:\Temp\`, `\'`, `a ' AND file:name = 'b`} {
+		pattern, _, ok := stixPattern("filename", value)
+		if !ok {
+			t.Fatalf("stixPattern(%q) failed", value)
+		}
+		want := [][2]string{{"file:name", value}}
+		if got := parseStixPattern(pattern); !reflect.DeepEqual(got, want) {
+			t.Errorf("value %q: parsed %q from %s", value, got, pattern)
+		}
+	}
+}
+
+func TestUUID5(t *testing.T) {
+	dns := [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	if got, want := uuid5(dns, "python.org"), "886313e1-3b8a-5372-9b90-0c9aee199e5d"; got != want {
+		t.Errorf("uuid5 = %s, want %s", got, want)
+	}
+}
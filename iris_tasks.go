@@ -0,0 +1,214 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// CaseTasksResponse represents the response of the /case/tasks/list endpoint
+type CaseTasksResponse struct {
+	Data struct {
+		Tasks []CaseTask `json:"tasks"`
+	} `json:"data"`
+	ApiMeta
+}
+
+// CaseTaskAPIResponse represents the response of a single case task api action
+type CaseTaskAPIResponse struct {
+	Task CaseTask `json:"data"`
+	ApiMeta
+}
+
+// CaseTask represents a task of a case
+type CaseTask struct {
+	TaskID           int              `json:"task_id"`
+	TaskUUID         string           `json:"task_uuid"`
+	TaskTitle        string           `json:"task_title"`
+	TaskDescription  string           `json:"task_description"`
+	TaskTags         string           `json:"task_tags"`
+	TaskStatusID     int              `json:"task_status_id"`
+	StatusName       string           `json:"status_name"`
+	TaskOpenDate     string           `json:"task_open_date"`
+	TaskCloseDate    string           `json:"task_close_date"`
+	TaskLastUpdate   string           `json:"task_last_update"`
+	TaskAssignees    []TaskAssignee   `json:"task_assignees"`
+	CustomAttributes CustomAttributes `json:"custom_attributes"`
+}
+
+// TaskAssignee represents a user assigned to a task
+type TaskAssignee struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	User string `json:"user"`
+}
+
+// CaseTaskRequest represents a struct for adding or updating a task of a case
+type CaseTaskRequest struct {
+	TaskTitle        string          `json:"task_title"`
+	TaskStatusID     int             `json:"task_status_id"`
+	TaskDescription  string          `json:"task_description,omitempty"`
+	TaskTags         string          `json:"task_tags,omitempty"`
+	TaskAssigneesID  []int           `json:"task_assignees_id,omitempty"`
+	CustomAttributes AttributeValues `json:"custom_attributes,omitempty"`
+}
+
+// ListCaseTasks gets all tasks of a case from the /case/tasks/list endpoint.
+//
+// Returns:
+// - *CaseTasksResponse*: The response from the API containing the tasks in the Data.Tasks field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListCaseTasks(caseId int) (*CaseTasksResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/case/tasks/list").
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var caseTasksResponse CaseTasksResponse
+	if err := json.NewDecoder(req.Body).Decode(&caseTasksResponse); err != nil {
+		return nil, err
+	}
+
+	return &caseTasksResponse, nil
+}
+
+// GetCaseTask returns a single task of a case from the /case/tasks/<task-id> endpoint.
+//
+// Returns:
+// - *CaseTaskAPIResponse*: The response from the API containing the task in the Task field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetCaseTask(caseId int, taskId int) (*CaseTaskAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/tasks/%d", taskId)).
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var caseTaskResponse CaseTaskAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&caseTaskResponse); err != nil {
+		return nil, err
+	}
+
+	return &caseTaskResponse, nil
+}
+
+// AddCaseTask adds a task to a case through the /case/tasks/add endpoint.
+//
+// Returns:
+// - *CaseTaskAPIResponse*: The response from the API containing the created task in the Task field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddCaseTask(caseId int, task CaseTaskRequest) (*CaseTaskAPIResponse, error) {
+	jsondata, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/case/tasks/add").
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var caseTaskResponse CaseTaskAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&caseTaskResponse); err != nil {
+		return nil, err
+	}
+
+	return &caseTaskResponse, nil
+}
+
+// UpdateCaseTask updates a task of a case through the /case/tasks/update/<task-id> endpoint.
+//
+// Returns:
+// - *CaseTaskAPIResponse*: The response from the API containing the updated task in the Task field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateCaseTask(caseId int, taskId int, task CaseTaskRequest) (*CaseTaskAPIResponse, error) {
+	jsondata, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/tasks/update/%d", taskId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var caseTaskResponse CaseTaskAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&caseTaskResponse); err != nil {
+		return nil, err
+	}
+
+	return &caseTaskResponse, nil
+}
+
+// DeleteCaseTask removes a task from a case using the /case/tasks/delete/<task-id> endpoint.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) DeleteCaseTask(caseId int, taskId int) error {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/tasks/delete/%d", taskId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// TimelineDateFormat is the layout IRIS uses for event dates, the timezone is sent separately in event_tz
+const TimelineDateFormat = "2006-01-02T15:04:05.000"
+
+// TimelineEventsResponse represents the response of the /case/timeline/advanced-filter endpoint
+type TimelineEventsResponse struct {
+	Data struct {
+		Events []TimelineEvent `json:"tim"`
+	} `json:"data"`
+	ApiMeta
+}
+
+// TimelineEventAPIResponse represents the response of a single timeline event api action
+type TimelineEventAPIResponse struct {
+	Event TimelineEvent `json:"data"`
+	ApiMeta
+}
+
+// TimelineEvent represents an event of the case timeline. Assets and Iocs are only filled by the list endpoint.
+type TimelineEvent struct {
+	EventID          int                  `json:"event_id"`
+	EventUUID        string               `json:"event_uuid"`
+	EventTitle       string               `json:"event_title"`
+	EventDate        string               `json:"event_date"`
+	EventTz          string               `json:"event_tz"`
+	EventContent     string               `json:"event_content"`
+	EventRaw         string               `json:"event_raw"`
+	EventSource      string               `json:"event_source"`
+	EventTags        string               `json:"event_tags"`
+	EventColor       string               `json:"event_color"`
+	EventInSummary   bool                 `json:"event_in_summary"`
+	EventInGraph     bool                 `json:"event_in_graph"`
+	EventCategoryID  int                  `json:"event_category_id"`
+	CategoryName     string               `json:"category_name"`
+	ParentEventID    int                  `json:"parent_event_id"`
+	Assets           []TimelineEventAsset `json:"assets"`
+	Iocs             []TimelineEventIoc   `json:"iocs"`
+	CustomAttributes CustomAttributes     `json:"custom_attributes"`
+}
+
+// TimelineEventAsset is an asset linked to a timeline event
+type TimelineEventAsset struct {
+	AssetID   int    `json:"asset_id"`
+	AssetName string `json:"asset_name"`
+}
+
+// TimelineEventIoc is an IOC linked to a timeline event
+type TimelineEventIoc struct {
+	IocID    int    `json:"ioc_id"`
+	IocValue string `json:"ioc_value"`
+}
+
+// Time returns the date of the event with its timezone
+func (event TimelineEvent) Time() (time.Time, error) {
+	tz := event.EventTz
+	if tz == "" {
+		tz = "+00:00"
+	}
+	// the fraction is optional, IRIS omits it for events created without milliseconds
+	return time.Parse("2006-01-02T15:04:05.999999999-07:00", event.EventDate+tz)
+}
+
+// TimelineEventRequest represents a struct for adding or updating a timeline event.
+// EventDate uses TimelineDateFormat and EventTz an offset such as "+00:00".
+type TimelineEventRequest struct {
+	EventTitle          string          `json:"event_title"`
+	EventDate           string          `json:"event_date"`
+	EventTz             string          `json:"event_tz"`
+	EventCategoryID     int             `json:"event_category_id"`
+	EventAssets         []int           `json:"event_assets"`
+	EventIocs           []int           `json:"event_iocs"`
+	EventContent        string          `json:"event_content,omitempty"`
+	EventRaw            string          `json:"event_raw,omitempty"`
+	EventSource         string          `json:"event_source,omitempty"`
+	EventTags           string          `json:"event_tags,omitempty"`
+	EventColor          string          `json:"event_color,omitempty"`
+	EventInSummary      bool            `json:"event_in_summary"`
+	EventInGraph        bool            `json:"event_in_graph"`
+	EventSyncIocsAssets bool            `json:"event_sync_iocs_assets"`
+	ParentEventID       int             `json:"parent_event_id,omitempty"`
+	CustomAttributes    AttributeValues `json:"custom_attributes,omitempty"`
+}
+
+// ListTimelineEvents gets all timeline events of a case from the /case/timeline/advanced-filter endpoint.
+//
+// Returns:
+// - *TimelineEventsResponse*: The response from the API containing the events in the Data.Events field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListTimelineEvents(caseId int) (*TimelineEventsResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/case/timeline/advanced-filter").
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddQueryParam("q", "{}").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var timelineEventsResponse TimelineEventsResponse
+	if err := json.NewDecoder(req.Body).Decode(&timelineEventsResponse); err != nil {
+		return nil, err
+	}
+
+	return &timelineEventsResponse, nil
+}
+
+// GetTimelineEvent returns a single timeline event from the /case/timeline/events/<event-id> endpoint.
+//
+// Returns:
+// - *TimelineEventAPIResponse*: The response from the API containing the event in the Event field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetTimelineEvent(caseId int, eventId int) (*TimelineEventAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/timeline/events/%d", eventId)).
+		SetMethod(http.MethodGet).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var timelineEventResponse TimelineEventAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&timelineEventResponse); err != nil {
+		return nil, err
+	}
+
+	return &timelineEventResponse, nil
+}
+
+// AddTimelineEvent adds an event to the timeline of a case through the /case/timeline/events/add endpoint.
+//
+// Example usage:
+//
+//	categoryId, _ := client.Lookup().EventCategoryID("Lateral Movement")
+//	event, err := client.AddTimelineEvent(42, goiris.TimelineEventRequest{
+//		EventTitle:      "RDP logon from WKS-0042",
+//		EventDate:       when.UTC().Format(goiris.TimelineDateFormat),
+//		EventTz:         "+00:00",
+//		EventCategoryID: categoryId,
+//		EventAssets:     []int{assetId},
+//		EventIocs:       []int{},
+//	})
+//
+// Returns:
+// - *TimelineEventAPIResponse*: The response from the API containing the created event in the Event field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) AddTimelineEvent(caseId int, event TimelineEventRequest) (*TimelineEventAPIResponse, error) {
+	jsondata, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL("/case/timeline/events/add").
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var timelineEventResponse TimelineEventAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&timelineEventResponse); err != nil {
+		return nil, err
+	}
+
+	return &timelineEventResponse, nil
+}
+
+// UpdateTimelineEvent updates a timeline event through the /case/timeline/events/update/<event-id> endpoint.
+//
+// Returns:
+// - *TimelineEventAPIResponse*: The response from the API containing the updated event in the Event field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) UpdateTimelineEvent(caseId int, eventId int, event TimelineEventRequest) (*TimelineEventAPIResponse, error) {
+	jsondata, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/timeline/events/update/%d", eventId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		SetBody(jsondata).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var timelineEventResponse TimelineEventAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&timelineEventResponse); err != nil {
+		return nil, err
+	}
+
+	return &timelineEventResponse, nil
+}
+
+// DeleteTimelineEvent removes a timeline event using the /case/timeline/events/delete/<event-id> endpoint.
+//
+// Returns:
+// - error: An error if the request fails.
+func (client *APIClient) DeleteTimelineEvent(caseId int, eventId int) error {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/case/timeline/events/delete/%d", eventId)).
+		SetMethod(http.MethodPost).
+		AddQueryParam("cid", strconv.Itoa(caseId)).
+		AddHeader("Content-Type", "application/json").
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package goiris
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimelineExportFormat is the file format of ExportTimeline
+type TimelineExportFormat string
+
+const (
+	// TimelineExportCSV writes one row per event with a header row
+	TimelineExportCSV TimelineExportFormat = "csv"
+	// TimelineExportJSON writes a JSON array of TimelineExportEvent
+	TimelineExportJSON TimelineExportFormat = "json"
+	// TimelineExportTimesketch writes the JSONL format Timesketch imports
+	TimelineExportTimesketch TimelineExportFormat = "timesketch"
+)
+
+// TimelineExportDateFormat is the default layout of the exported event times
+const TimelineExportDateFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// TimelineExportOptions controls ExportTimeline
+type TimelineExportOptions struct {
+	// Location is the timezone the times are converted to, the timezone of each event if nil
+	Location *time.Location
+	// TimeLayout is the layout of Datetime, TimelineExportDateFormat if empty
+	TimeLayout string
+}
+
+// TimelineExportEvent is an exported timeline event with the names of its category, assets and IOCs
+type TimelineExportEvent struct {
+	ID       int       `json:"id"`
+	UUID     string    `json:"uuid"`
+	Time     time.Time `json:"-"`
+	Datetime string    `json:"datetime"`
+	Title    string    `json:"title"`
+	Content  string    `json:"content,omitempty"`
+	Source   string    `json:"source,omitempty"`
+	Category string    `json:"category,omitempty"`
+	Tags     []string  `json:"tags,omitempty"`
+	Assets   []string  `json:"assets,omitempty"`
+	Iocs     []string  `json:"iocs,omitempty"`
+	Raw      string    `json:"raw,omitempty"`
+}
+
+var timelineExportHeaders = []string{"id", "datetime", "title", "content", "source", "category", "tags", "assets", "iocs", "raw"}
+
+// ExportTimeline writes all timeline events of a case in the given format.
+//
+// Example usage:
+//
+//	file, _ := os.Create("timeline.csv")
+//	defer file.Close()
+//	count, err := client.ExportTimeline(1, file, goiris.TimelineExportCSV, goiris.TimelineExportOptions{Location: time.UTC})
+//
+// Returns:
+// - int: The number of exported events.
+// - error: An error if the events cannot be fetched or written.
+func (client *APIClient) ExportTimeline(caseId int, w io.Writer, format TimelineExportFormat, options TimelineExportOptions) (int, error) {
+	response, err := client.ListTimelineEvents(caseId)
+	if err != nil {
+		return 0, fmt.Errorf("list timeline events: %w", err)
+	}
+
+	// the list endpoint only names the category in newer IRIS versions
+	for i, event := range response.Data.Events {
+		if event.CategoryName == "" && event.EventCategoryID != 0 {
+			if name, err := client.Lookup().Name(LookupEventCategory, event.EventCategoryID); err == nil {
+				response.Data.Events[i].CategoryName = name
+			}
+		}
+	}
+
+	events, err := NewTimelineExport(response.Data.Events, options)
+	if err != nil {
+		return 0, err
+	}
+	if err := WriteTimeline(w, events, format); err != nil {
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+// NewTimelineExport converts timeline events to TimelineExportEvent sorted by time.
+//
+// Returns:
+// - []TimelineExportEvent: The converted events.
+// - error: An error if the date of an event cannot be parsed.
+func NewTimelineExport(events []TimelineEvent, options TimelineExportOptions) ([]TimelineExportEvent, error) {
+	layout := options.TimeLayout
+	if layout == "" {
+		layout = TimelineExportDateFormat
+	}
+
+	exported := make([]TimelineExportEvent, 0, len(events))
+	for _, event := range events {
+		t, err := event.Time()
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", event.EventID, err)
+		}
+		if options.Location != nil {
+			t = t.In(options.Location)
+		}
+
+		export := TimelineExportEvent{
+			ID:       event.EventID,
+			UUID:     event.EventUUID,
+			Time:     t,
+			Datetime: t.Format(layout),
+			Title:    event.EventTitle,
+			Content:  event.EventContent,
+			Source:   event.EventSource,
+			Category: event.CategoryName,
+			Tags:     splitList(event.EventTags, ","),
+			Raw:      event.EventRaw,
+		}
+		for _, asset := range event.Assets {
+			export.Assets = append(export.Assets, asset.AssetName)
+		}
+		for _, ioc := range event.Iocs {
+			export.Iocs = append(export.Iocs, ioc.IocValue)
+		}
+		exported = append(exported, export)
+	}
+
+	sort.SliceStable(exported, func(i, j int) bool {
+		return exported[i].Time.Before(exported[j].Time)
+	})
+
+	return exported, nil
+}
+
+// WriteTimeline writes exported events in the given format. CSV joins tags, assets and IOCs with ", ".
+// Timesketch lines carry the title as message, the source as data_type and the first asset as hostname.
+//
+// Returns:
+// - error: An error if the format is unknown or writing fails.
+func WriteTimeline(w io.Writer, events []TimelineExportEvent, format TimelineExportFormat) error {
+	switch format {
+	case TimelineExportCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(timelineExportHeaders); err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := writer.Write([]string{
+				fmt.Sprint(event.ID), event.Datetime, event.Title, event.Content, event.Source, event.Category,
+				strings.Join(event.Tags, ", "), strings.Join(event.Assets, ", "), strings.Join(event.Iocs, ", "), event.Raw,
+			}); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case TimelineExportJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(events)
+
+	case TimelineExportTimesketch:
+		encoder := json.NewEncoder(w)
+		for _, event := range events {
+			line := map[string]interface{}{
+				"message":         event.Title,
+				"datetime":        event.Time.Format(time.RFC3339Nano),
+				"timestamp":       event.Time.UnixMicro(),
+				"timestamp_desc":  "Event Time",
+				"iris_event_id":   event.ID,
+				"iris_event_uuid": event.UUID,
+				"tag":             append([]string{}, event.Tags...),
+			}
+			for key, value := range map[string]string{
+				"content":   event.Content,
+				"data_type": event.Source,
+				"category":  event.Category,
+				"raw":       event.Raw,
+			} {
+				if value != "" {
+					line[key] = value
+				}
+			}
+			if len(event.Assets) > 0 {
+				line["hostname"] = event.Assets[0]
+				line["assets"] = event.Assets
+			}
+			if len(event.Iocs) > 0 {
+				line["iocs"] = event.Iocs
+			}
+			if err := encoder.Encode(line); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown timeline export format %q", format)
+}
@@ -0,0 +1,572 @@
+package goiris
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimelineRecord is a single row of a forensic timeline, the common form of all timeline parsers
+type TimelineRecord struct {
+	Time     time.Time
+	Title    string
+	Content  string
+	Source   string
+	Host     string
+	Category string
+	Tags     []string
+	// Raw is the original line of the timeline
+	Raw string
+}
+
+// TimelineCSVMapping names the CSV columns of the record fields, only Time and Title are required.
+// Columns are matched case-insensitively against the header row.
+type TimelineCSVMapping struct {
+	Time     string
+	Title    string
+	Content  string
+	Source   string
+	Host     string
+	Category string
+	Tags     string
+
+	// TimeLayout is the layout of the time column. If empty RFC 3339, "2006-01-02 15:04:05" with optional
+	// fraction and Unix seconds are tried.
+	TimeLayout string
+	// Location is the timezone of times without offset, UTC if nil
+	Location *time.Location
+	// Comma is the field delimiter, ',' if zero
+	Comma rune
+	// TagSeparator splits the tags column, ',' if empty
+	TagSeparator string
+}
+
+// PlasoCSVMapping is the column mapping of the Plaso psort "dynamic" CSV output
+var PlasoCSVMapping = TimelineCSVMapping{
+	Time:    "datetime",
+	Title:   "message",
+	Content: "timestamp_desc",
+	Source:  "parser",
+	Host:    "hostname",
+	Tags:    "tag",
+}
+
+// TimelineFilter selects the records to import, zero fields do not filter
+type TimelineFilter struct {
+	From time.Time
+	To   time.Time
+	// Tagged keeps only records with at least one tag
+	Tagged bool
+	// Tags keeps only records with one of the tags
+	Tags []string
+	// Hosts keeps only records of one of the hosts
+	Hosts []string
+	// Match keeps only records whose title or content matches
+	Match *regexp.Regexp
+	// Func keeps only records it returns true for
+	Func func(TimelineRecord) bool
+}
+
+// TimelineImportOptions controls ImportTimeline
+type TimelineImportOptions struct {
+	Filter TimelineFilter
+	// Category is the event category of records without category, "Unspecified" if empty
+	Category string
+	// Location is the timezone the events are stored in, UTC if nil
+	Location *time.Location
+	// LinkAssets links events to the case assets whose name is the host of the record
+	LinkAssets bool
+	// Tags are added to every event
+	Tags      []string
+	InSummary bool
+	InGraph   bool
+	// Workers is the number of events created concurrently, 4 if zero
+	Workers int
+	// DryRun only reports what would be created
+	DryRun bool
+}
+
+// TimelineImportResult is the outcome of ImportTimeline
+type TimelineImportResult struct {
+	// Created are the created events, or the events that would be created with DryRun
+	Created    []TimelineEventRequest
+	Filtered   int
+	Duplicates int
+	// Linked is the number of events linked to an asset
+	Linked int
+}
+
+// ParseTimelineCSV parses a CSV timeline with a header row using the given column mapping.
+//
+// Example usage:
+//
+//	file, _ := os.Open("supertimeline.csv")
+//	records, err := goiris.ParseTimelineCSV(file, goiris.PlasoCSVMapping)
+//
+// Returns:
+// - []TimelineRecord: The rows of the timeline.
+// - error: An error if a required column is missing or a time cannot be parsed, with its line.
+func ParseTimelineCSV(r io.Reader, mapping TimelineCSVMapping) ([]TimelineRecord, error) {
+	reader := csv.NewReader(r)
+	if mapping.Comma != 0 {
+		reader.Comma = mapping.Comma
+	}
+	reader.FieldsPerRecord = -1
+	separator := mapping.TagSeparator
+	if separator == "" {
+		separator = ","
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	column := func(name string, required bool) (int, error) {
+		if name == "" && !required {
+			return -1, nil
+		}
+		i, ok := columns[strings.ToLower(name)]
+		if !ok {
+			if required {
+				return -1, fmt.Errorf("timeline csv: column %q missing", name)
+			}
+			return -1, nil
+		}
+		return i, nil
+	}
+
+	var indexes [7]int
+	for i, field := range []struct {
+		name     string
+		required bool
+	}{
+		{mapping.Time, true},
+		{mapping.Title, true},
+		{mapping.Content, false},
+		{mapping.Source, false},
+		{mapping.Host, false},
+		{mapping.Category, false},
+		{mapping.Tags, false},
+	} {
+		if indexes[i], err = column(field.name, field.required); err != nil {
+			return nil, err
+		}
+	}
+
+	var records []TimelineRecord
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		value := func(i int) string {
+			if i < 0 || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+
+		t, err := parseTimelineTime(value(indexes[0]), mapping.TimeLayout, mapping.Location)
+		if err != nil {
+			return nil, fmt.Errorf("timeline csv line %d: %w", line, err)
+		}
+
+		var raw strings.Builder
+		writer := csv.NewWriter(&raw)
+		writer.Comma = reader.Comma
+		_ = writer.Write(row)
+		writer.Flush()
+
+		records = append(records, TimelineRecord{
+			Time:     t,
+			Title:    value(indexes[1]),
+			Content:  value(indexes[2]),
+			Source:   value(indexes[3]),
+			Host:     value(indexes[4]),
+			Category: value(indexes[5]),
+			Tags:     splitList(value(indexes[6]), separator),
+			Raw:      strings.TrimSuffix(raw.String(), "\n"),
+		})
+	}
+
+	return records, nil
+}
+
+// ParsePlasoJSONL parses the json_line output of Plaso psort. The message is the title, the timestamp
+// description the content, the parser the source.
+//
+// Returns:
+// - []TimelineRecord: The events of the timeline.
+// - error: An error if a line is not JSON or has no time, with its line number.
+func ParsePlasoJSONL(r io.Reader) ([]TimelineRecord, error) {
+	return parseTimelineJSONL(r, "plaso", func(fields map[string]interface{}) TimelineRecord {
+		return TimelineRecord{
+			Title:   jsonString(fields, "message"),
+			Content: strings.TrimSpace(jsonString(fields, "timestamp_desc") + " " + jsonString(fields, "display_name")),
+			Source:  firstNonEmpty(jsonString(fields, "parser"), jsonString(fields, "data_type")),
+			Host:    jsonString(fields, "hostname"),
+		}
+	})
+}
+
+// ParseTimesketchJSONL parses a timeline in the JSONL format Timesketch imports and exports.
+// The message is the title, the content or else the timestamp description the content, the data type the source.
+//
+// Returns:
+// - []TimelineRecord: The events of the timeline.
+// - error: An error if a line is not JSON or has no time, with its line number.
+func ParseTimesketchJSONL(r io.Reader) ([]TimelineRecord, error) {
+	return parseTimelineJSONL(r, "timesketch", func(fields map[string]interface{}) TimelineRecord {
+		return TimelineRecord{
+			Title:    jsonString(fields, "message"),
+			Content:  firstNonEmpty(jsonString(fields, "content"), jsonString(fields, "timestamp_desc")),
+			Source:   firstNonEmpty(jsonString(fields, "data_type"), jsonString(fields, "source_short")),
+			Host:     firstNonEmpty(jsonString(fields, "hostname"), jsonString(fields, "computer_name")),
+			Category: jsonString(fields, "category"),
+		}
+	})
+}
+
+// parseTimelineJSONL parses one JSON object per line, the time is read from "datetime" or the
+// microsecond "timestamp", tags from "tag" as list or {"labels": [...]}
+func parseTimelineJSONL(r io.Reader, format string, convert func(map[string]interface{}) TimelineRecord) ([]TimelineRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var records []TimelineRecord
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.UseNumber()
+		var fields map[string]interface{}
+		if err := decoder.Decode(&fields); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", format, line, err)
+		}
+
+		record := convert(fields)
+		var err error
+		switch {
+		case jsonString(fields, "datetime") != "":
+			record.Time, err = parseTimelineTime(jsonString(fields, "datetime"), "", nil)
+		case jsonString(fields, "timestamp") != "":
+			var micros int64
+			micros, err = strconv.ParseInt(jsonString(fields, "timestamp"), 10, 64)
+			record.Time = time.UnixMicro(micros).UTC()
+		default:
+			err = errors.New("no datetime or timestamp")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", format, line, err)
+		}
+
+		switch tags := fields["tag"].(type) {
+		case []interface{}:
+			record.Tags = jsonStrings(tags)
+		case map[string]interface{}:
+			if labels, ok := tags["labels"].([]interface{}); ok {
+				record.Tags = jsonStrings(labels)
+			}
+		case string:
+			record.Tags = splitList(tags, ",")
+		}
+		record.Raw = text
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s line %d: %w", format, line+1, err)
+	}
+
+	return records, nil
+}
+
+// Keep reports whether the record passes the filter
+func (filter TimelineFilter) Keep(record TimelineRecord) bool {
+	if !filter.From.IsZero() && record.Time.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && record.Time.After(filter.To) {
+		return false
+	}
+	if filter.Tagged && len(record.Tags) == 0 {
+		return false
+	}
+	if len(filter.Tags) > 0 && !anyEqualFold(filter.Tags, record.Tags) {
+		return false
+	}
+	if len(filter.Hosts) > 0 && !anyEqualFold(filter.Hosts, []string{record.Host, shortHostname(record.Host)}) {
+		return false
+	}
+	if filter.Match != nil && !filter.Match.MatchString(record.Title) && !filter.Match.MatchString(record.Content) {
+		return false
+	}
+	if filter.Func != nil && !filter.Func(record) {
+		return false
+	}
+	return true
+}
+
+// ImportTimeline creates timeline events in a case from parsed timeline records. Records are filtered with
+// options.Filter and deduplicated by time and title against each other and the events the case already has,
+// so the same timeline can be imported again. With options.LinkAssets, events are linked to the asset named
+// like the host of the record, also matching the short hostname.
+//
+// Example usage:
+//
+//	file, _ := os.Open("host1.plaso.jsonl")
+//	records, err := goiris.ParsePlasoJSONL(file)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := client.ImportTimeline(42, records, goiris.TimelineImportOptions{
+//		Filter:     goiris.TimelineFilter{Tagged: true},
+//		LinkAssets: true,
+//	})
+//
+// Returns:
+// - *TimelineImportResult*: The created events and the number of filtered and duplicate records.
+// - error: An error if the category is unknown or a request fails. Events created before are kept and
+// reported in the result.
+func (client *APIClient) ImportTimeline(caseId int, records []TimelineRecord, options TimelineImportOptions) (*TimelineImportResult, error) {
+	location := options.Location
+	if location == nil {
+		location = time.UTC
+	}
+	defaultCategory := options.Category
+	if defaultCategory == "" {
+		defaultCategory = "Unspecified"
+	}
+	workers := options.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+
+	existing, err := client.ListTimelineEvents(caseId)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, event := range existing.Data.Events {
+		if t, err := event.Time(); err == nil {
+			seen[timelineKey(t, event.EventTitle)] = true
+		}
+	}
+
+	assets := make(map[string]int)
+	if options.LinkAssets {
+		caseAssets, err := client.ListAssets(caseId)
+		if err != nil {
+			return nil, err
+		}
+		for _, asset := range caseAssets.Data.Assets {
+			assets[strings.ToLower(asset.AssetName)] = asset.AssetID
+			if _, ok := assets[strings.ToLower(shortHostname(asset.AssetName))]; !ok {
+				assets[strings.ToLower(shortHostname(asset.AssetName))] = asset.AssetID
+			}
+		}
+	}
+
+	result := &TimelineImportResult{}
+	var events []TimelineEventRequest
+	for _, record := range records {
+		if !options.Filter.Keep(record) {
+			result.Filtered++
+			continue
+		}
+		key := timelineKey(record.Time, record.Title)
+		if seen[key] {
+			result.Duplicates++
+			continue
+		}
+		seen[key] = true
+
+		category := record.Category
+		if category == "" {
+			category = defaultCategory
+		}
+		categoryId, err := client.Lookup().EventCategoryID(category)
+		if err != nil {
+			return nil, err
+		}
+
+		t := record.Time.In(location)
+		event := TimelineEventRequest{
+			EventTitle:      record.Title,
+			EventDate:       t.Format(TimelineDateFormat),
+			EventTz:         t.Format("-07:00"),
+			EventCategoryID: categoryId,
+			EventAssets:     []int{},
+			EventIocs:       []int{},
+			EventContent:    record.Content,
+			EventRaw:        record.Raw,
+			EventSource:     record.Source,
+			EventTags:       strings.Join(append(append([]string(nil), options.Tags...), record.Tags...), ","),
+			EventInSummary:  options.InSummary,
+			EventInGraph:    options.InGraph,
+		}
+		if record.Host != "" {
+			id, ok := assets[strings.ToLower(record.Host)]
+			if !ok {
+				id, ok = assets[strings.ToLower(shortHostname(record.Host))]
+			}
+			if ok {
+				event.EventAssets = []int{id}
+				result.Linked++
+			}
+		}
+		events = append(events, event)
+	}
+
+	if options.DryRun {
+		result.Created = events
+		return result, nil
+	}
+
+	var mu sync.Mutex
+	var firstErr error
+	created := make([]bool, len(events))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				_, err := client.AddTimelineEvent(caseId, events[i])
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("event %q at %s: %w", events[i].EventTitle, events[i].EventDate, err)
+				}
+				created[i] = err == nil
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range events {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, ok := range created {
+		if ok {
+			result.Created = append(result.Created, events[i])
+		}
+	}
+	return result, firstErr
+}
+
+// timelineKey identifies an event for deduplication, by its time in milliseconds and its title
+func timelineKey(t time.Time, title string) string {
+	return strconv.FormatInt(t.UnixMilli(), 10) + "|" + strings.ToLower(strings.TrimSpace(title))
+}
+
+// parseTimelineTime parses t with layout, or the common timeline layouts if layout is empty
+func parseTimelineTime(t, layout string, location *time.Location) (time.Time, error) {
+	if location == nil {
+		location = time.UTC
+	}
+	if layout != "" {
+		return time.ParseInLocation(layout, t, location)
+	}
+
+	for _, layout := range []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999",
+	} {
+		if parsed, err := time.ParseInLocation(layout, t, location); err == nil {
+			return parsed, nil
+		}
+	}
+	if seconds, err := strconv.ParseInt(t, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse time %q", t)
+}
+
+// shortHostname returns the host name without domain, "ws1" for "ws1.corp.example"
+func shortHostname(host string) string {
+	short, _, _ := strings.Cut(host, ".")
+	return short
+}
+
+func anyEqualFold(want, have []string) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if strings.EqualFold(w, h) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func splitList(s, separator string) []string {
+	var split []string
+	for _, item := range strings.Split(s, separator) {
+		if item = strings.TrimSpace(item); item != "" {
+			split = append(split, item)
+		}
+	}
+	return split
+}
+
+func jsonString(fields map[string]interface{}, key string) string {
+	switch value := fields[key].(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	return ""
+}
+
+func jsonStrings(values []interface{}) []string {
+	var strs []string
+	for _, value := range values {
+		if s, ok := value.(string); ok && s != "" {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
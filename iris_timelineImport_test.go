@@ -0,0 +1,270 @@
+package goiris
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimelineCSV(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no timezone database:", err)
+	}
+
+	tests := []struct {
+		name    string
+		csv     string
+		mapping TimelineCSVMapping
+		want    []TimelineRecord
+		err     string
+	}{
+		{
+			name:    "plaso dynamic csv",
+			csv:     "datetime,timestamp_desc,source,message,parser,hostname,tag\n2024-03-01T10:00:00.123456+00:00,Creation Time,FILE,C:/evil.exe created,filestat,WS1.corp.example,\"malware, triage\"\n",
+			mapping: PlasoCSVMapping,
+			want: []TimelineRecord{{
+				Time:    time.Date(2024, 3, 1, 10, 0, 0, 123456000, time.UTC),
+				Title:   "C:/evil.exe created",
+				Content: "Creation Time",
+				Source:  "filestat",
+				Host:    "WS1.corp.example",
+				Tags:    []string{"malware", "triage"},
+				Raw:     "2024-03-01T10:00:00.123456+00:00,Creation Time,FILE,C:/evil.exe created,filestat,WS1.corp.example,\"malware, triage\"",
+			}},
+		},
+		{
+			name:    "byte order mark and header case",
+			csv:     "\ufeffTime,Title\n2024-03-01 10:00:00,logon\n",
+			mapping: TimelineCSVMapping{Time: "time", Title: "title"},
+			want:    []TimelineRecord{{Time: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), Title: "logon", Raw: "2024-03-01 10:00:00,logon"}},
+		},
+		{
+			name:    "delimiter, layout, location and tag separator",
+			csv:     "when;what;labels\n01.03.2024 11:00;logon;a|b\n",
+			mapping: TimelineCSVMapping{Time: "when", Title: "what", Tags: "labels", TimeLayout: "02.01.2006 15:04", Location: berlin, Comma: ';', TagSeparator: "|"},
+			want:    []TimelineRecord{{Time: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), Title: "logon", Tags: []string{"a", "b"}, Raw: "01.03.2024 11:00;logon;a|b"}},
+		},
+		{
+			name:    "unix seconds and short rows",
+			csv:     "time,title,host\n1709287200,logon\n",
+			mapping: TimelineCSVMapping{Time: "time", Title: "title", Host: "host"},
+			want:    []TimelineRecord{{Time: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), Title: "logon", Raw: "1709287200,logon"}},
+		},
+		{
+			name:    "missing required column",
+			csv:     "time,message\n",
+			mapping: TimelineCSVMapping{Time: "time", Title: "title"},
+			err:     `column "title" missing`,
+		},
+		{
+			name:    "missing optional column",
+			csv:     "time,title\n2024-03-01T10:00:00Z,logon\n",
+			mapping: TimelineCSVMapping{Time: "time", Title: "title", Host: "host"},
+			want:    []TimelineRecord{{Time: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), Title: "logon", Raw: "2024-03-01T10:00:00Z,logon"}},
+		},
+		{
+			name:    "invalid time reports its line",
+			csv:     "time,title\n2024-03-01T10:00:00Z,a\nyesterday,b\n",
+			mapping: TimelineCSVMapping{Time: "time", Title: "title"},
+			err:     "line 3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			records, err := ParseTimelineCSV(strings.NewReader(test.csv), test.mapping)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("error = %v, want %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertTimelineRecords(t, records, test.want)
+		})
+	}
+}
+
+func TestParseTimelineJSONL(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(string) ([]TimelineRecord, error)
+		jsonl string
+		want  []TimelineRecord
+		err   string
+	}{
+		{
+			name:  "plaso",
+			parse: func(s string) ([]TimelineRecord, error) { return ParsePlasoJSONL(strings.NewReader(s)) },
+			jsonl: `{"datetime":"2024-03-01T10:00:00.000000+00:00","message":"evil.exe executed","timestamp_desc":"Last Time Executed","display_name":"NTFS:\\Prefetch","parser":"prefetch","hostname":"ws1","tag":["malware"]}` + "\n\n",
+			want: []TimelineRecord{{
+				Time:    time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+				Title:   "evil.exe executed",
+				Content: `Last Time Executed NTFS:\Prefetch`,
+				Source:  "prefetch",
+				Host:    "ws1",
+				Tags:    []string{"malware"},
+			}},
+		},
+		{
+			name:  "plaso falls back to the data type",
+			parse: func(s string) ([]TimelineRecord, error) { return ParsePlasoJSONL(strings.NewReader(s)) },
+			jsonl: `{"timestamp":1709287200000000,"message":"logon","data_type":"windows:evtx:record","tag":"a, b"}`,
+			want:  []TimelineRecord{{Time: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), Title: "logon", Source: "windows:evtx:record", Tags: []string{"a", "b"}}},
+		},
+		{
+			name:  "timesketch",
+			parse: func(s string) ([]TimelineRecord, error) { return ParseTimesketchJSONL(strings.NewReader(s)) },
+			jsonl: `{"timestamp":1709287200123456,"message":"logon","timestamp_desc":"Event Recorded","data_type":"windows:evtx:record","computer_name":"ws1","category":"Lateral movement","tag":{"labels":["lateral"]}}`,
+			want: []TimelineRecord{{
+				Time:     time.Date(2024, 3, 1, 10, 0, 0, 123456000, time.UTC),
+				Title:    "logon",
+				Content:  "Event Recorded",
+				Source:   "windows:evtx:record",
+				Host:     "ws1",
+				Category: "Lateral movement",
+				Tags:     []string{"lateral"},
+			}},
+		},
+		{
+			name:  "timesketch prefers content and hostname",
+			parse: func(s string) ([]TimelineRecord, error) { return ParseTimesketchJSONL(strings.NewReader(s)) },
+			jsonl: `{"datetime":"2024-03-01T10:00:00Z","message":"logon","content":"details","timestamp_desc":"Event Recorded","source_short":"EVT","hostname":"ws1","computer_name":"ws2"}`,
+			want:  []TimelineRecord{{Time: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), Title: "logon", Content: "details", Source: "EVT", Host: "ws1"}},
+		},
+		{
+			name:  "no time",
+			parse: func(s string) ([]TimelineRecord, error) { return ParseTimesketchJSONL(strings.NewReader(s)) },
+			jsonl: `{"datetime":"2024-03-01T10:00:00Z","message":"a"}` + "\n" + `{"message":"b"}`,
+			err:   "timesketch line 2: no datetime or timestamp",
+		},
+		{
+			name:  "not json",
+			parse: func(s string) ([]TimelineRecord, error) { return ParsePlasoJSONL(strings.NewReader(s)) },
+			jsonl: "\n{\"message\":",
+			err:   "plaso line 2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			records, err := test.parse(test.jsonl)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("error = %v, want %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range records {
+				if records[i].Raw == "" {
+					t.Errorf("record %d: raw line missing", i)
+				}
+				records[i].Raw = ""
+			}
+			assertTimelineRecords(t, records, test.want)
+		})
+	}
+}
+
+func TestTimelineKey(t *testing.T) {
+	at := time.Date(2024, 3, 1, 10, 0, 0, 123000000, time.UTC)
+	tests := []struct {
+		name   string
+		a, b   time.Time
+		ta, tb string
+		equal  bool
+	}{
+		{"same", at, at, "logon", "logon", true},
+		{"title case and spaces", at, at, "Logon ", " logon", true},
+		{"other timezone, same instant", at, at.In(time.FixedZone("", 2*3600)), "logon", "logon", true},
+		{"below a millisecond", at, at.Add(999 * time.Microsecond), "logon", "logon", true},
+		{"other millisecond", at, at.Add(time.Millisecond), "logon", "logon", false},
+		{"other title", at, at, "logon", "logoff", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if equal := timelineKey(test.a, test.ta) == timelineKey(test.b, test.tb); equal != test.equal {
+				t.Errorf("keys equal = %v, want %v", equal, test.equal)
+			}
+		})
+	}
+}
+
+func TestImportTimelineDeduplicates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/case/timeline/advanced-filter":
+			fmt.Fprint(w, `{"status":"success","data":{"tim":[{"event_id":1,"event_title":"Logon","event_date":"2024-03-01T11:00:00.000","event_tz":"+01:00"}]}}`)
+		case "/case/assets/list":
+			fmt.Fprint(w, `{"status":"success","data":{"assets":[{"asset_id":7,"asset_name":"WS1.corp.example"}]}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &APIClient{BaseURL: server.URL, AuthStrategy: &ApiKeyAuth{ApiKey: "test"}, Client: *NewMyHttpClient()}
+	client.lookup = NewLookup(client, time.Hour)
+	client.lookup.tables[LookupEventCategory] = &lookupTable{
+		fetchedAt: time.Now(),
+		ids:       map[string]int{"unspecified": 1, "lateral movement": 2},
+		names:     map[int]string{1: "Unspecified", 2: "Lateral movement"},
+	}
+
+	at := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	records := []TimelineRecord{
+		{Time: at, Title: "logon"},                              // in the case already
+		{Time: at.Add(time.Minute), Title: "copy", Host: "ws1"}, // linked by short hostname
+		{Time: at.Add(time.Minute), Title: "Copy "},             // duplicate of the record before
+		{Time: at.Add(2 * time.Minute), Title: "psexec", Category: "Lateral movement"},
+		{Time: at.Add(-time.Hour), Title: "early"}, // filtered
+	}
+
+	result, err := client.ImportTimeline(1, records, TimelineImportOptions{
+		Filter:     TimelineFilter{From: at.Add(-time.Minute)},
+		LinkAssets: true,
+		DryRun:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Filtered != 1 || result.Duplicates != 2 || result.Linked != 1 {
+		t.Errorf("filtered %d, duplicates %d, linked %d, want 1, 2, 1", result.Filtered, result.Duplicates, result.Linked)
+	}
+	if len(result.Created) != 2 {
+		t.Fatalf("created %d events, want 2", len(result.Created))
+	}
+	if event := result.Created[0]; event.EventTitle != "copy" || !reflect.DeepEqual(event.EventAssets, []int{7}) || event.EventCategoryID != 1 {
+		t.Errorf("first event = %+v", event)
+	}
+	if event := result.Created[1]; event.EventCategoryID != 2 || event.EventDate != "2024-03-01T10:02:00.000" || event.EventTz != "+00:00" {
+		t.Errorf("second event = %+v", event)
+	}
+}
+
+func assertTimelineRecords(t *testing.T, got, want []TimelineRecord) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d records, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Time.Equal(want[i].Time) {
+			t.Errorf("record %d: time %s, want %s", i, got[i].Time, want[i].Time)
+		}
+		got[i].Time, want[i].Time = time.Time{}, time.Time{}
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("record %d:\n got %+v\nwant %+v", i, got[i], want[i])
+		}
+	}
+}
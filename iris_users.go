@@ -0,0 +1,97 @@
+package goiris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// UsersResponse represents the response of the /manage/users/list endpoint
+type UsersResponse struct {
+	Users []User `json:"data"`
+	ApiMeta
+}
+
+// UserAPIResponse represents the response of a single user api action
+type UserAPIResponse struct {
+	User User `json:"data"`
+	ApiMeta
+}
+
+// User represents an IRIS user account. Groups and case access are only filled by GetUser.
+type User struct {
+	UserID               int          `json:"user_id"`
+	UserUUID             string       `json:"user_uuid"`
+	UserName             string       `json:"user_name"`
+	UserLogin            string       `json:"user_login"`
+	UserEmail            string       `json:"user_email"`
+	UserActive           bool         `json:"user_active"`
+	UserIsServiceAccount bool         `json:"user_is_service_account"`
+	UserGroups           []UserGroup  `json:"user_groups,omitempty"`
+	UserCasesAccess      []CaseAccess `json:"user_cases_access,omitempty"`
+}
+
+// UserGroup is a group a user is member of
+type UserGroup struct {
+	GroupID   int    `json:"group_id"`
+	GroupUUID string `json:"group_uuid"`
+	GroupName string `json:"group_name"`
+}
+
+// ListUsers gets all users of the instance from the /manage/users/list endpoint.
+//
+// Returns:
+// - *UsersResponse*: The response from the API containing the users in the Users field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) ListUsers() (*UsersResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL("/manage/users/list").
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var usersResponse UsersResponse
+	if err := json.NewDecoder(req.Body).Decode(&usersResponse); err != nil {
+		return nil, err
+	}
+
+	return &usersResponse, nil
+}
+
+// GetUser returns a single user from the /manage/users/<user-id> endpoint.
+//
+// Returns:
+// - *UserAPIResponse*: The response from the API containing the user in the User field.
+// - error: An error if the request fails or the response cannot be decoded.
+func (client *APIClient) GetUser(userId int) (*UserAPIResponse, error) {
+	builder := NewRequestBuilder().
+		SetURL(fmt.Sprintf("/manage/users/%d", userId)).
+		SetMethod(http.MethodGet).
+		Build()
+
+	req, err := client.DoRequest(*builder)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close()
+
+	if req.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", req.StatusCode)
+	}
+
+	var userResponse UserAPIResponse
+	if err := json.NewDecoder(req.Body).Decode(&userResponse); err != nil {
+		return nil, err
+	}
+
+	return &userResponse, nil
+}
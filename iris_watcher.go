@@ -0,0 +1,403 @@
+package goiris
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// WatchKind is an object type polled by Watcher
+type WatchKind string
+
+const (
+	WatchCases      WatchKind = "case"
+	WatchAlerts     WatchKind = "alert"
+	WatchActivities WatchKind = "activity"
+)
+
+// WatchChange is the kind of change of a WatchEvent. Activities are only created.
+type WatchChange string
+
+const (
+	WatchCreated WatchChange = "created"
+	WatchUpdated WatchChange = "updated"
+	WatchDeleted WatchChange = "deleted"
+)
+
+// WatchEvent is a change found by Watcher. Case, Alert or Activity is set according to Kind,
+// deleted objects only have their ID.
+type WatchEvent struct {
+	Kind     WatchKind
+	Change   WatchChange
+	ID       int
+	Case     *Case
+	Alert    *Alert
+	Activity *Activity
+	// PolledAt is the time of the poll that found the change
+	PolledAt time.Time
+}
+
+// CursorStore persists the state of a Watcher between runs
+type CursorStore interface {
+	// LoadCursor returns the cursor saved under key, nil if there is none
+	LoadCursor(key string) ([]byte, error)
+	SaveCursor(key string, cursor []byte) error
+}
+
+// MemoryCursorStore keeps cursors in memory, it is the default store of Watcher
+type MemoryCursorStore struct {
+	mu      sync.Mutex
+	cursors map[string][]byte
+}
+
+// NewMemoryCursorStore creates an empty MemoryCursorStore
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{cursors: map[string][]byte{}}
+}
+
+// LoadCursor returns the cursor saved under key
+func (store *MemoryCursorStore) LoadCursor(key string) ([]byte, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.cursors[key], nil
+}
+
+// SaveCursor saves the cursor under key
+func (store *MemoryCursorStore) SaveCursor(key string, cursor []byte) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.cursors[key] = append([]byte(nil), cursor...)
+	return nil
+}
+
+// FileCursorStore keeps each cursor in the file <Dir>/<key>.json
+type FileCursorStore struct {
+	Dir string
+}
+
+// LoadCursor reads the cursor file of key
+func (store FileCursorStore) LoadCursor(key string) ([]byte, error) {
+	cursor, err := os.ReadFile(store.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return cursor, err
+}
+
+// SaveCursor replaces the cursor file of key
+func (store FileCursorStore) SaveCursor(key string, cursor []byte) error {
+	if err := os.MkdirAll(store.Dir, 0o700); err != nil {
+		return err
+	}
+	// write and rename so a crash never leaves a truncated cursor
+	tmp := store.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, cursor, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, store.path(key))
+}
+
+func (store FileCursorStore) path(key string) string {
+	return filepath.Join(store.Dir, key+".json")
+}
+
+// WatcherOptions controls a Watcher
+type WatcherOptions struct {
+	// Kinds are the polled object types, all if empty
+	Kinds []WatchKind
+	// Interval is the time between polls, one minute if zero
+	Interval time.Duration
+	// AlertFilter limits the watched alerts, its paging fields are ignored
+	AlertFilter AlertFilter
+	// Store persists the cursor, a MemoryCursorStore if nil
+	Store CursorStore
+	// Key is the name of the cursor in the store, "watcher" if empty
+	Key string
+	// EmitExisting reports all objects as created on the first poll without cursor, otherwise the first
+	// poll only records them
+	EmitExisting bool
+	// Buffer is the capacity of the events channel, 64 if zero
+	Buffer int
+	// OnError is called with the errors of failed polls, the watcher keeps running
+	OnError func(error)
+}
+
+// Watcher polls cases, alerts and the activity log and reports the changes since the last poll. The
+// cursor is saved after the events of a poll were delivered, so a restarted watcher resumes where it
+// stopped and may repeat the events of an interrupted poll.
+//
+// Example usage:
+//
+//	watcher := client.NewWatcher(goiris.WatcherOptions{
+//		Kinds: []goiris.WatchKind{goiris.WatchCases, goiris.WatchAlerts},
+//		Store: goiris.FileCursorStore{Dir: "/var/lib/iris-watch"},
+//	})
+//	go watcher.Run(ctx)
+//	for event := range watcher.Events() {
+//		log.Printf("%s %s %d", event.Kind, event.Change, event.ID)
+//	}
+type Watcher struct {
+	client  *APIClient
+	options WatcherOptions
+	events  chan WatchEvent
+	cursor  watchCursor
+}
+
+type watchCursor struct {
+	Cases      map[int]string  `json:"cases"`
+	Alerts     map[int]string  `json:"alerts"`
+	Activities *activityCursor `json:"activities,omitempty"`
+}
+
+// activityCursor is the time of the newest activity and the activities of that time
+type activityCursor struct {
+	Time time.Time `json:"time"`
+	Seen []string  `json:"seen"`
+}
+
+// NewWatcher creates a Watcher, Run starts polling
+func (client *APIClient) NewWatcher(options WatcherOptions) *Watcher {
+	if len(options.Kinds) == 0 {
+		options.Kinds = []WatchKind{WatchCases, WatchAlerts, WatchActivities}
+	}
+	if options.Interval <= 0 {
+		options.Interval = time.Minute
+	}
+	if options.Store == nil {
+		options.Store = NewMemoryCursorStore()
+	}
+	if options.Key == "" {
+		options.Key = "watcher"
+	}
+	if options.Buffer <= 0 {
+		options.Buffer = 64
+	}
+	if options.OnError == nil {
+		options.OnError = func(error) {}
+	}
+
+	return &Watcher{client: client, options: options, events: make(chan WatchEvent, options.Buffer)}
+}
+
+// Events returns the channel of the changes, it is closed when Run returns
+func (watcher *Watcher) Events() <-chan WatchEvent {
+	return watcher.events
+}
+
+// Run polls until ctx is done.
+//
+// Returns:
+// - error: The error of ctx, or an error if the cursor cannot be loaded or saved.
+func (watcher *Watcher) Run(ctx context.Context) error {
+	defer close(watcher.events)
+
+	saved, err := watcher.options.Store.LoadCursor(watcher.options.Key)
+	if err != nil {
+		return fmt.Errorf("load cursor: %w", err)
+	}
+	if saved != nil {
+		if err := json.Unmarshal(saved, &watcher.cursor); err != nil {
+			return fmt.Errorf("load cursor: %w", err)
+		}
+	}
+
+	ticker := time.NewTicker(watcher.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		events, next := watcher.poll()
+		for _, event := range events {
+			select {
+			case watcher.events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		cursor, err := json.Marshal(next)
+		if err != nil {
+			return err
+		}
+		if err := watcher.options.Store.SaveCursor(watcher.options.Key, cursor); err != nil {
+			return fmt.Errorf("save cursor: %w", err)
+		}
+		watcher.cursor = next
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll diffs every kind against the cursor, the cursor of a failed kind is kept
+func (watcher *Watcher) poll() ([]WatchEvent, watchCursor) {
+	now := time.Now()
+	next := watcher.cursor
+	var events []WatchEvent
+
+	for _, kind := range watcher.options.Kinds {
+		var err error
+		switch kind {
+		case WatchCases:
+			var found []WatchEvent
+			found, next.Cases, err = watcher.pollCases(now)
+			events = append(events, found...)
+		case WatchAlerts:
+			var found []WatchEvent
+			found, next.Alerts, err = watcher.pollAlerts(now)
+			events = append(events, found...)
+		case WatchActivities:
+			var found []WatchEvent
+			found, next.Activities, err = watcher.pollActivities(now)
+			events = append(events, found...)
+		default:
+			err = fmt.Errorf("unknown watch kind %q", kind)
+		}
+		if err != nil {
+			watcher.options.OnError(fmt.Errorf("poll %s: %w", kind, err))
+		}
+	}
+
+	return events, next
+}
+
+func (watcher *Watcher) pollCases(now time.Time) ([]WatchEvent, map[int]string, error) {
+	response, err := watcher.client.ListCases()
+	if err != nil {
+		return nil, watcher.cursor.Cases, err
+	}
+
+	objects := map[int]interface{}{}
+	for i := range response.Cases {
+		objects[response.Cases[i].CaseID] = &response.Cases[i]
+	}
+	events, seen := watcher.diff(WatchCases, watcher.cursor.Cases, objects, now)
+	for i := range events {
+		if c, ok := objects[events[i].ID].(*Case); ok {
+			events[i].Case = c
+		}
+	}
+	return events, seen, nil
+}
+
+func (watcher *Watcher) pollAlerts(now time.Time) ([]WatchEvent, map[int]string, error) {
+	filter := watcher.options.AlertFilter
+	filter.PerPage = 100
+	objects := map[int]interface{}{}
+	for filter.Page = 1; ; filter.Page++ {
+		response, err := watcher.client.FilterAlerts(filter)
+		if err != nil {
+			return nil, watcher.cursor.Alerts, err
+		}
+		for i := range response.Data.Alerts {
+			objects[response.Data.Alerts[i].AlertID] = &response.Data.Alerts[i]
+		}
+		if response.Data.NextPage == nil || len(response.Data.Alerts) == 0 || filter.Page >= response.Data.LastPage {
+			break
+		}
+	}
+
+	events, seen := watcher.diff(WatchAlerts, watcher.cursor.Alerts, objects, now)
+	for i := range events {
+		if alert, ok := objects[events[i].ID].(*Alert); ok {
+			events[i].Alert = alert
+		}
+	}
+	return events, seen, nil
+}
+
+func (watcher *Watcher) pollActivities(now time.Time) ([]WatchEvent, *activityCursor, error) {
+	previous := watcher.cursor.Activities
+	emit := previous != nil || watcher.options.EmitExisting
+	if previous == nil {
+		previous = &activityCursor{}
+	}
+
+	activities, err := watcher.client.FilterActivities(ActivityFilter{From: previous.Time})
+	if err != nil {
+		return nil, watcher.cursor.Activities, err
+	}
+
+	seen := map[string]bool{}
+	for _, key := range previous.Seen {
+		seen[key] = true
+	}
+
+	var events []WatchEvent
+	next := &activityCursor{Time: previous.Time, Seen: previous.Seen}
+	for i := range activities {
+		activity := &activities[i]
+		key := activityKey(*activity)
+		// FilterActivities sorted and parsed them already
+		t, _ := activity.Time()
+		if t.Equal(previous.Time) && seen[key] {
+			continue
+		}
+		if emit {
+			events = append(events, WatchEvent{Kind: WatchActivities, Change: WatchCreated, Activity: activity, PolledAt: now})
+		}
+		if !t.Equal(next.Time) {
+			next = &activityCursor{Time: t}
+		}
+		next.Seen = append(next.Seen, key)
+	}
+	return events, next, nil
+}
+
+// diff compares objects by fingerprint with the previous poll, a nil previous is the first poll
+func (watcher *Watcher) diff(kind WatchKind, previous map[int]string, objects map[int]interface{}, now time.Time) ([]WatchEvent, map[int]string) {
+	emit := previous != nil || watcher.options.EmitExisting
+	seen := make(map[int]string, len(objects))
+	var events []WatchEvent
+
+	ids := make([]int, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		fingerprint := watchFingerprint(objects[id])
+		seen[id] = fingerprint
+		if !emit {
+			continue
+		}
+		if old, ok := previous[id]; !ok {
+			events = append(events, WatchEvent{Kind: kind, Change: WatchCreated, ID: id, PolledAt: now})
+		} else if old != fingerprint {
+			events = append(events, WatchEvent{Kind: kind, Change: WatchUpdated, ID: id, PolledAt: now})
+		}
+	}
+	var deleted []int
+	for id := range previous {
+		if _, ok := objects[id]; !ok {
+			deleted = append(deleted, id)
+		}
+	}
+	sort.Ints(deleted)
+	for _, id := range deleted {
+		events = append(events, WatchEvent{Kind: kind, Change: WatchDeleted, ID: id, PolledAt: now})
+	}
+
+	return events, seen
+}
+
+func watchFingerprint(object interface{}) string {
+	data, _ := json.Marshal(object)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
+func activityKey(activity Activity) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d\x00%s\x00%s", activity.ActivityDate, activity.CaseID, activity.UserName, activity.ActivityDesc)))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,405 @@
+package goiris
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IRIS hooks the webhook handler has typed events for. IRIS calls the hooks of deleted objects with the
+// object ID only, the typed event then only has its ID field set.
+const (
+	WebhookCaseCreated  = "on_postload_case_create"
+	WebhookCaseUpdated  = "on_postload_case_info_update"
+	WebhookCaseDeleted  = "on_postload_case_delete"
+	WebhookIocCreated   = "on_postload_ioc_create"
+	WebhookIocUpdated   = "on_postload_ioc_update"
+	WebhookIocDeleted   = "on_postload_ioc_delete"
+	WebhookAssetCreated = "on_postload_asset_create"
+	WebhookAssetUpdated = "on_postload_asset_update"
+	WebhookAssetDeleted = "on_postload_asset_delete"
+	WebhookAlertCreated = "on_postload_alert_create"
+	WebhookAlertUpdated = "on_postload_alert_update"
+	WebhookAlertDeleted = "on_postload_alert_delete"
+)
+
+// Default headers checked by WebhookHandler
+const (
+	WebhookSignatureHeader = "X-Iris-Signature"
+	WebhookTokenHeader     = "X-Iris-Token"
+)
+
+var (
+	// ErrWebhookUnauthorized is returned by Verify when the request has no valid signature or token
+	ErrWebhookUnauthorized = errors.New("webhook signature or token invalid")
+	// ErrWebhookPayload is returned when the body is not a webhook payload
+	ErrWebhookPayload = errors.New("invalid webhook payload")
+)
+
+// WebhookPayload is the JSON body the handler expects. Configure the request body of the IRIS webhooks
+// module to send the hook name, the case ID and the object, or list of objects, of the hook as data.
+type WebhookPayload struct {
+	Hook   string          `json:"hook"`
+	CaseID int             `json:"case_id"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// WebhookEvent is a single object of a webhook call
+type WebhookEvent struct {
+	Hook       string
+	CaseID     int
+	Data       json.RawMessage
+	ReceivedAt time.Time
+}
+
+// CaseEvent is a webhook event of a case hook
+type CaseEvent struct {
+	WebhookEvent
+	Case Case
+}
+
+// IocEvent is a webhook event of an IOC hook
+type IocEvent struct {
+	WebhookEvent
+	Ioc Ioc
+}
+
+// AssetEvent is a webhook event of an asset hook
+type AssetEvent struct {
+	WebhookEvent
+	Asset Asset
+}
+
+// AlertEvent is a webhook event of an alert hook
+type AlertEvent struct {
+	WebhookEvent
+	Alert Alert
+}
+
+// WebhookHandler is an http.Handler that verifies and decodes IRIS webhook calls and dispatches them to
+// the registered functions. The objects of a call are decoded for every typed handler before the first
+// handler runs, a call with an object that cannot be decoded is answered with 400 and reaches no handler.
+// Handlers of a call run in registration order; if one fails the call is answered with 500 so the sender
+// can retry.
+//
+// Example usage:
+//
+//	hooks := goiris.NewWebhookHandler(os.Getenv("IRIS_WEBHOOK_SECRET"))
+//	hooks.OnIoc(func(event goiris.IocEvent) error {
+//		log.Printf("case %d: new IOC %s", event.CaseID, event.Ioc.IocValue)
+//		return nil
+//	}, goiris.WebhookIocCreated)
+//	http.Handle("/iris", hooks)
+type WebhookHandler struct {
+	// Secret verifies the calls. A call is accepted if the signature header has the hex HMAC-SHA256 of
+	// the body, optionally prefixed with "sha256=", or the token header or a bearer Authorization header
+	// equals the secret. Without a secret every call is rejected unless Insecure is set.
+	Secret string
+	// Insecure accepts calls without verification if Secret is empty, for receivers behind a proxy that
+	// already authenticates IRIS
+	Insecure bool
+	// SignatureHeader is WebhookSignatureHeader if empty
+	SignatureHeader string
+	// TokenHeader is WebhookTokenHeader if empty
+	TokenHeader string
+	// MaxBodySize limits the body, 10 MiB if zero
+	MaxBodySize int64
+
+	mu       sync.RWMutex
+	handlers map[string][]webhookHandlerFunc
+	fallback []webhookHandlerFunc
+}
+
+// webhookHandlerFunc decodes an event for a registered function and returns the call of the function
+type webhookHandlerFunc func(WebhookEvent) (func() error, error)
+
+// webhookCall is a decoded event ready to be passed to a registered function
+type webhookCall struct {
+	hook string
+	call func() error
+}
+
+// NewWebhookHandler creates a WebhookHandler verifying calls with the given secret. An empty secret
+// rejects every call, set Insecure on the handler to accept unverified calls.
+func NewWebhookHandler(secret string) *WebhookHandler {
+	return &WebhookHandler{Secret: secret}
+}
+
+// On registers fn for the given hooks, or for every hook if none are given
+func (handler *WebhookHandler) On(fn func(WebhookEvent) error, hooks ...string) {
+	handler.on(func(event WebhookEvent) (func() error, error) {
+		return func() error { return fn(event) }, nil
+	}, hooks...)
+}
+
+func (handler *WebhookHandler) on(fn webhookHandlerFunc, hooks ...string) {
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+
+	if len(hooks) == 0 {
+		handler.fallback = append(handler.fallback, fn)
+		return
+	}
+	if handler.handlers == nil {
+		handler.handlers = map[string][]webhookHandlerFunc{}
+	}
+	for _, hook := range hooks {
+		handler.handlers[hook] = append(handler.handlers[hook], fn)
+	}
+}
+
+// OnCase registers fn for the given case hooks, or all of them if none are given
+func (handler *WebhookHandler) OnCase(fn func(CaseEvent) error, hooks ...string) {
+	if len(hooks) == 0 {
+		hooks = []string{WebhookCaseCreated, WebhookCaseUpdated, WebhookCaseDeleted}
+	}
+	handler.on(func(event WebhookEvent) (func() error, error) {
+		typed := CaseEvent{WebhookEvent: event}
+		if err := decodeWebhookObject(event.Data, &typed.Case, &typed.Case.CaseID); err != nil {
+			return nil, err
+		}
+		if typed.CaseID == 0 {
+			typed.CaseID = typed.Case.CaseID
+		}
+		return func() error { return fn(typed) }, nil
+	}, hooks...)
+}
+
+// OnIoc registers fn for the given IOC hooks, or all of them if none are given
+func (handler *WebhookHandler) OnIoc(fn func(IocEvent) error, hooks ...string) {
+	if len(hooks) == 0 {
+		hooks = []string{WebhookIocCreated, WebhookIocUpdated, WebhookIocDeleted}
+	}
+	handler.on(func(event WebhookEvent) (func() error, error) {
+		typed := IocEvent{WebhookEvent: event}
+		if err := decodeWebhookObject(event.Data, &typed.Ioc, &typed.Ioc.IocID); err != nil {
+			return nil, err
+		}
+		return func() error { return fn(typed) }, nil
+	}, hooks...)
+}
+
+// OnAsset registers fn for the given asset hooks, or all of them if none are given
+func (handler *WebhookHandler) OnAsset(fn func(AssetEvent) error, hooks ...string) {
+	if len(hooks) == 0 {
+		hooks = []string{WebhookAssetCreated, WebhookAssetUpdated, WebhookAssetDeleted}
+	}
+	handler.on(func(event WebhookEvent) (func() error, error) {
+		typed := AssetEvent{WebhookEvent: event}
+		if err := decodeWebhookObject(event.Data, &typed.Asset, &typed.Asset.AssetID); err != nil {
+			return nil, err
+		}
+		return func() error { return fn(typed) }, nil
+	}, hooks...)
+}
+
+// OnAlert registers fn for the given alert hooks, or all of them if none are given
+func (handler *WebhookHandler) OnAlert(fn func(AlertEvent) error, hooks ...string) {
+	if len(hooks) == 0 {
+		hooks = []string{WebhookAlertCreated, WebhookAlertUpdated, WebhookAlertDeleted}
+	}
+	handler.on(func(event WebhookEvent) (func() error, error) {
+		typed := AlertEvent{WebhookEvent: event}
+		if err := decodeWebhookObject(event.Data, &typed.Alert, &typed.Alert.AlertID); err != nil {
+			return nil, err
+		}
+		return func() error { return fn(typed) }, nil
+	}, hooks...)
+}
+
+// ServeHTTP verifies, decodes and dispatches a webhook call. It answers 405 to other methods than POST,
+// 401 to unverified calls, 400 to invalid payloads, 500 if a handler fails and 204 otherwise.
+func (handler *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit := handler.MaxBodySize
+	if limit == 0 {
+		limit = 10 << 20
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err := handler.Verify(r.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	events, err := ParseWebhookPayload(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	calls, err := handler.decode(events)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := runWebhookCalls(calls); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Verify checks the signature or token of a call against Secret.
+//
+// Returns:
+// - error: ErrWebhookUnauthorized if neither the signature nor the token match, or if Secret is empty and
+// Insecure is not set.
+func (handler *WebhookHandler) Verify(header http.Header, body []byte) error {
+	if handler.Secret == "" {
+		if handler.Insecure {
+			return nil
+		}
+		return fmt.Errorf("%w: no secret set", ErrWebhookUnauthorized)
+	}
+
+	signatureHeader := handler.SignatureHeader
+	if signatureHeader == "" {
+		signatureHeader = WebhookSignatureHeader
+	}
+	if signature := header.Get(signatureHeader); signature != "" {
+		got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+		if err == nil && hmac.Equal(got, webhookMAC(handler.Secret, body)) {
+			return nil
+		}
+		return ErrWebhookUnauthorized
+	}
+
+	tokenHeader := handler.TokenHeader
+	if tokenHeader == "" {
+		tokenHeader = WebhookTokenHeader
+	}
+	token := header.Get(tokenHeader)
+	if token == "" {
+		token = strings.TrimPrefix(header.Get("Authorization"), "Bearer ")
+	}
+	if token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(handler.Secret)) == 1 {
+		return nil
+	}
+	return ErrWebhookUnauthorized
+}
+
+// Dispatch calls the handlers registered for the hooks of the events. No handler is called if an event
+// cannot be decoded for one of them.
+//
+// Returns:
+// - error: ErrWebhookPayload if an event cannot be decoded, otherwise the joined errors of the failed handlers.
+func (handler *WebhookHandler) Dispatch(events ...WebhookEvent) error {
+	calls, err := handler.decode(events)
+	if err != nil {
+		return err
+	}
+	return runWebhookCalls(calls)
+}
+
+// decode decodes the events for every handler registered for their hooks
+func (handler *WebhookHandler) decode(events []WebhookEvent) ([]webhookCall, error) {
+	handler.mu.RLock()
+	defer handler.mu.RUnlock()
+
+	var calls []webhookCall
+	for _, event := range events {
+		fns := make([]webhookHandlerFunc, 0, len(handler.handlers[event.Hook])+len(handler.fallback))
+		fns = append(append(fns, handler.handlers[event.Hook]...), handler.fallback...)
+		for _, fn := range fns {
+			call, err := fn(event)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", event.Hook, err)
+			}
+			calls = append(calls, webhookCall{hook: event.Hook, call: call})
+		}
+	}
+	return calls, nil
+}
+
+func runWebhookCalls(calls []webhookCall) error {
+	var errs []error
+	for _, call := range calls {
+		if err := call.call(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", call.hook, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ParseWebhookPayload decodes a webhook body into one event per object of its data.
+//
+// Returns:
+// - []WebhookEvent: The events of the call.
+// - error: ErrWebhookPayload if the body is not JSON or has no hook.
+func ParseWebhookPayload(body []byte) ([]WebhookEvent, error) {
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookPayload, err)
+	}
+	if payload.Hook == "" {
+		return nil, fmt.Errorf("%w: no hook", ErrWebhookPayload)
+	}
+
+	// IRIS passes the objects of most hooks as list
+	objects := []json.RawMessage{payload.Data}
+	if data := bytes.TrimSpace(payload.Data); len(data) > 0 && data[0] == '[' {
+		objects = nil
+		if err := json.Unmarshal(data, &objects); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrWebhookPayload, err)
+		}
+	}
+
+	received := time.Now()
+	events := make([]WebhookEvent, 0, len(objects))
+	for _, object := range objects {
+		events = append(events, WebhookEvent{Hook: payload.Hook, CaseID: payload.CaseID, Data: object, ReceivedAt: received})
+	}
+	return events, nil
+}
+
+// SignWebhook returns the signature header value of a body, for senders and tests
+func SignWebhook(secret string, body []byte) string {
+	return "sha256=" + hex.EncodeToString(webhookMAC(secret, body))
+}
+
+func webhookMAC(secret string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// decodeWebhookObject decodes data into object, or into id if the hook only passed the object ID
+func decodeWebhookObject(data json.RawMessage, object interface{}, id *int) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if data[0] != '{' {
+		value, err := strconv.Atoi(strings.Trim(string(data), `"`))
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrWebhookPayload, err)
+		}
+		*id = value
+		return nil
+	}
+	if err := json.Unmarshal(data, object); err != nil {
+		return fmt.Errorf("%w: %v", ErrWebhookPayload, err)
+	}
+	return nil
+}
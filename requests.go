@@ -1,15 +1,19 @@
 package goiris
 
+import "net/url"
+
 type RequestBuilder struct {
 	Method  string
 	URL     string
 	Headers map[string]string
+	Query   url.Values
 	Body    interface{}
 }
 
 func NewRequestBuilder() *RequestBuilder {
 	return &RequestBuilder{
 		Headers: make(map[string]string),
+		Query:   make(url.Values),
 	}
 }
 
@@ -28,6 +32,11 @@ func (rb *RequestBuilder) AddHeader(key, value string) *RequestBuilder {
 	return rb
 }
 
+func (rb *RequestBuilder) AddQueryParam(key, value string) *RequestBuilder {
+	rb.Query.Add(key, value)
+	return rb
+}
+
 func (rb *RequestBuilder) SetBody(body interface{}) *RequestBuilder {
 	rb.Body = body
 	return rb
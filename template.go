@@ -11,6 +11,8 @@ type APIClient struct {
 	AuthStrategy AuthStrategy
 	BaseURL      string
 	Client       MyHttpClient
+
+	lookup *Lookup
 }
 
 func (client *APIClient) DoRequest(builder RequestBuilder) (*http.Response, error) {
@@ -19,9 +21,16 @@ func (client *APIClient) DoRequest(builder RequestBuilder) (*http.Response, erro
 		return nil, err
 	}
 
+	if len(builder.Query) > 0 {
+		url += "?" + builder.Query.Encode()
+	}
+
 	var body io.Reader = http.NoBody
-	if builder.Body != nil {
-		body = bytes.NewReader(builder.Body.([]byte))
+	switch b := builder.Body.(type) {
+	case []byte:
+		body = bytes.NewReader(b)
+	case io.Reader:
+		body = b
 	}
 
 	req, err := http.NewRequest(builder.Method, url, body)